@@ -0,0 +1,695 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	api "groupie/models"
+)
+
+// App est l'application Groupie Tracker : la fenêtre, les données chargées et les écrans
+// Elle possède tout l'état de l'interface ; les vues (ListView, DetailsView, MapView, FilterPanel)
+// ne font que l'afficher et signalent les actions de l'utilisateur par leurs événements
+// Elle se construit sans lancer la boucle d'événements, ce qui permet de la piloter
+// dans les tests avec le pilote de test de Fyne
+type App struct {
+	fyne fyne.App
+	win  fyne.Window
+	nav  *router
+
+	artists  []api.Artist          // Tous les artistes, triés selon l'ordre choisi
+	concerts map[int][]api.Concert // Concerts par ID d'artiste
+	byID     map[int]api.Artist    // Accès aux artistes par ID pour les routes (artist/42, map/42...)
+	favs     *favorites            // Favoris sauvegardés entre les sessions
+	presets  *presets              // Recherches enregistrées entre les sessions
+
+	store       *Store       // Filtres et résultats de la recherche, observés par les vues
+	selectedIDs map[int]bool // Artistes cochés : comparaison (2 à 4) et export .ics
+
+	calendar *calendarView // Construit à la première ouverture
+	stats    *api.Stats    // Calculées à la première ouverture
+	details  *DetailsView  // Page détails affichée
+
+	// Page liste, construite une seule fois : elle garde sa position
+	// de défilement et sa sélection quand on y revient
+	listPage      fyne.CanvasObject
+	listView      *ListView
+	filters       *FilterPanel
+	search        *widget.Entry
+	favOnly       *widget.Check
+	sortSelect    *widget.Select
+	statFilterBtn *widget.Button
+	compareBtn    *widget.Button
+	icsBtn        *widget.Button
+}
+
+// NewApp crée la fenêtre principale à partir des artistes et de l'index de leurs concerts
+// La liste est affichée ; Run lance la boucle d'événements
+func NewApp(fyneApp fyne.App, artists []api.Artist, concerts map[int][]api.Concert) *App {
+	g := &App{
+		fyne:        fyneApp,
+		nav:         newRouter(),
+		artists:     artists,
+		concerts:    concerts,
+		byID:        make(map[int]api.Artist, len(artists)),
+		favs:        loadFavorites(fyneApp.Preferences()),
+		presets:     loadPresets(fyneApp.Preferences()),
+		selectedIDs: map[int]bool{},
+	}
+	for _, a := range artists {
+		g.byID[a.ID] = a
+	}
+
+	g.win = fyneApp.NewWindow("Groupie Tracker")
+	g.win.Resize(fyne.NewSize(90, 70))
+	g.win.CenterOnScreen()
+
+	// Tri initial selon l'ordre sauvegardé
+	order, ok := api.ParseSortOrder(fyneApp.Preferences().String(prefSortOrder))
+	if !ok {
+		order = api.SortNameAsc
+	}
+	api.SortArtists(g.artists, order, g.concerts, nil)
+	g.store = newStore(g.artists, g.concerts, g.favs, order)
+
+	g.buildListPage()
+	g.registerRoutes()
+	g.registerShortcuts()
+
+	g.nav.Navigate(Route{Kind: RouteList})
+	return g
+}
+
+// rebuild reconstruit les vues, ex: après un changement de langue ou de données
+// Les filtres en cours sont conservés ; la sélection et le filtre des statistiques sont retirés
+func (g *App) rebuild() {
+	state := g.store.State()
+	g.store = newStore(g.artists, g.concerts, g.favs, state.Sort)
+	g.store.Apply(state)
+	g.selectedIDs = map[int]bool{}
+	g.calendar, g.stats, g.details = nil, nil, nil
+
+	g.buildListPage()
+	if !g.nav.Reload() {
+		g.nav.Navigate(Route{Kind: RouteList})
+	}
+}
+
+// catalogData regroupe les artistes et l'index de leurs concerts
+type catalogData struct {
+	artists  []api.Artist
+	concerts map[int][]api.Concert
+}
+
+// reload télécharge à nouveau les artistes et leurs concerts depuis l'API à l'adresse url,
+// puis reconstruit les vues. En cas d'erreur, l'adresse précédente et les données actuelles
+// sont gardées. done reçoit le résultat
+func (g *App) reload(url string, done func(ok bool)) {
+	previous := api.BaseURL()
+	api.SetBaseURL(url)
+	progress := dialog.NewCustomWithoutButtons(lang.L("Chargement des artistes..."), widget.NewProgressBarInfinite(), g.win)
+	progress.Show()
+	task := newTask(func() (catalogData, error) {
+		artists, concerts, err := api.FetchCatalog()
+		return catalogData{artists, concerts}, err
+	})
+	task.OnChange(func(t *Task[catalogData]) {
+		switch t.State() {
+		case TaskFailed:
+			progress.Hide()
+			api.SetBaseURL(previous)
+			dialog.ShowError(fmt.Errorf(lang.L("URL de l'API non modifiée : %s"), errorText(t.Err())), g.win)
+			done(false)
+		case TaskDone:
+			progress.Hide()
+			g.artists, g.concerts = t.Value().artists, t.Value().concerts
+			g.byID = make(map[int]api.Artist, len(g.artists))
+			for _, a := range g.artists {
+				g.byID[a.ID] = a
+			}
+			g.rebuild()
+			done(true)
+		}
+	})
+}
+
+// Window renvoie la fenêtre principale
+func (g *App) Window() fyne.Window {
+	return g.win
+}
+
+// Navigate affiche une route, ex: un lien profond passé en argument
+func (g *App) Navigate(route Route) bool {
+	return g.nav.Navigate(route)
+}
+
+// Run affiche la fenêtre et lance la boucle d'événements
+func (g *App) Run() {
+	g.win.ShowAndRun()
+}
+
+// goBack revient à l'écran précédent, ou à la liste s'il n'y en a pas
+// (ex: page ouverte directement par un lien groupie://artist/42)
+func (g *App) goBack() {
+	if !g.nav.Back() {
+		g.nav.Navigate(Route{Kind: RouteList})
+	}
+}
+
+// openArtist affiche la page détails d'un artiste
+func (g *App) openArtist(a api.Artist) {
+	g.nav.Navigate(Route{Kind: RouteArtist, ID: a.ID})
+}
+
+// results renvoie les artistes trouvés par la recherche
+func (g *App) results() []api.Artist {
+	return g.store.Current().Artists
+}
+
+// toggleFavorite ajoute ou retire un artiste des favoris
+func (g *App) toggleFavorite(id int) {
+	g.favs.Toggle(id)
+	if g.details != nil && g.details.artist.ID == id {
+		g.details.SetFavorite(g.favs.Has(id))
+	}
+	g.store.Refresh()
+}
+
+// toggleSelected coche ou décoche un artiste pour la comparaison et l'export .ics
+func (g *App) toggleSelected(id int, checked bool) {
+	if checked {
+		g.selectedIDs[id] = true
+	} else {
+		delete(g.selectedIDs, id)
+	}
+	g.compareBtn.SetText(fmt.Sprintf(lang.L("Comparer (%d)"), len(g.selectedIDs)))
+	if len(g.selectedIDs) >= minCompared && len(g.selectedIDs) <= maxCompared {
+		g.compareBtn.Enable()
+	} else {
+		g.compareBtn.Disable()
+	}
+	g.icsBtn.SetText(fmt.Sprintf(".ics (%d)", len(g.selectedIDs)))
+	if len(g.selectedIDs) > 0 {
+		g.icsBtn.Enable()
+	} else {
+		g.icsBtn.Disable()
+	}
+}
+
+// selectedArtists renvoie les artistes cochés, dans l'ordre de la liste complète
+func (g *App) selectedArtists() []api.Artist {
+	var res []api.Artist
+	for _, a := range g.artists {
+		if g.selectedIDs[a.ID] {
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
+// setStatFilter applique un filtre venu du tableau de statistiques
+// Le bouton affiche le filtre actif et le retire au clic
+func (g *App) setStatFilter(label string, filter func(api.Artist) bool) {
+	if filter == nil {
+		g.statFilterBtn.Hide()
+	} else {
+		g.statFilterBtn.SetText(label)
+		g.statFilterBtn.Show()
+	}
+	g.store.SetStatFilter(filter)
+}
+
+// exportFavorites enregistre les favoris dans un fichier JSON
+func (g *App) exportFavorites() {
+	dialog.ShowFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+		defer writer.Close()
+		if err := g.favs.Export(writer); err != nil {
+			dialog.ShowError(err, g.win)
+		}
+	}, g.win)
+}
+
+// importFavorites ajoute aux favoris ceux d'un fichier JSON exporté
+func (g *App) importFavorites() {
+	dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil || reader == nil {
+			return
+		}
+		defer reader.Close()
+		added, err := g.favs.Import(reader)
+		if err != nil {
+			dialog.ShowError(err, g.win)
+			return
+		}
+		dialog.ShowInformation(lang.L("Favoris"), plural("%d favori(s) importé(s)", added), g.win)
+		g.store.Refresh()
+	}, g.win)
+}
+
+// applyPreset applique une recherche enregistrée et affiche la liste
+// Le filtre des statistiques, qui n'en fait pas partie, est retiré
+func (g *App) applyPreset(p Preset) {
+	g.setStatFilter("", nil)
+	g.store.Apply(p.State)
+	if g.nav.Current().Kind != RouteList {
+		g.nav.Navigate(Route{Kind: RouteList, Query: p.State.Query})
+	}
+}
+
+// savePreset demande un nom et enregistre la recherche actuelle
+func (g *App) savePreset() {
+	dialog.ShowEntryDialog(lang.L("Enregistrer la recherche"), lang.L("Nom :"), func(name string) {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			g.savePresetAs(name)
+		}
+	}, g.win)
+}
+
+// savePresetAs enregistre la recherche actuelle sous ce nom
+// Une recherche du même nom n'est remplacée qu'après confirmation
+func (g *App) savePresetAs(name string) {
+	preset := Preset{Name: name, State: g.store.State()}
+	if _, exists := g.presets.Get(name); !exists {
+		g.presets.Save(preset)
+		return
+	}
+	dialog.ShowConfirm(lang.L("Remplacer la recherche ?"),
+		fmt.Sprintf(lang.L("Une recherche nommée « %s » existe déjà. La remplacer ?"), name),
+		func(ok bool) {
+			if ok {
+				g.presets.Save(preset)
+			}
+		}, g.win)
+}
+
+// sharePreset copie une recherche enregistrée dans le presse-papiers et l'affiche
+func (g *App) sharePreset(p Preset) {
+	text := p.Share()
+	g.fyne.Clipboard().SetContent(text)
+	shared := widget.NewEntry()
+	shared.SetText(text)
+	shared.MultiLine = true
+	shared.Wrapping = fyne.TextWrapBreak
+	d := dialog.NewCustom(lang.L("Copié dans le presse-papiers"), lang.L("Fermer"), shared, g.win)
+	d.Resize(fyne.NewSize(500, 250))
+	d.Show()
+}
+
+// importPreset enregistre et applique une recherche partagée collée par l'utilisateur
+// Elle est renommée si une recherche enregistrée porte déjà son nom, ex: "Queen (2)"
+func (g *App) importPreset() {
+	shared := widget.NewMultiLineEntry()
+	shared.SetPlaceHolder(`{"name":"...","state":{...}}`)
+	shared.Wrapping = fyne.TextWrapBreak
+	d := dialog.NewForm(lang.L("Importer une recherche"), lang.L("Importer"), lang.L("Annuler"),
+		[]*widget.FormItem{widget.NewFormItem(lang.L("Recherche"), shared)}, func(ok bool) {
+			if !ok {
+				return
+			}
+			p, err := ParsePreset(shared.Text)
+			if err != nil {
+				dialog.ShowError(err, g.win)
+				return
+			}
+			p.Name = g.presets.FreeName(p.Name)
+			g.presets.Save(p)
+			g.applyPreset(p)
+		}, g.win)
+	d.Resize(fyne.NewSize(500, 300))
+	d.Show()
+}
+
+// presetMenu construit le menu des recherches enregistrées
+func (g *App) presetMenu() *fyne.Menu {
+	var items []*fyne.MenuItem
+	var share, remove []*fyne.MenuItem
+	for _, p := range g.presets.All() {
+		items = append(items, fyne.NewMenuItem(p.Name, func() { g.applyPreset(p) }))
+		share = append(share, fyne.NewMenuItem(p.Name, func() { g.sharePreset(p) }))
+		remove = append(remove, fyne.NewMenuItem(p.Name, func() { g.presets.Delete(p.Name) }))
+	}
+	if len(items) > 0 {
+		items = append(items, fyne.NewMenuItemSeparator())
+	}
+	items = append(items, fyne.NewMenuItem(lang.L("Enregistrer la recherche..."), g.savePreset))
+	items = append(items, fyne.NewMenuItem(lang.L("Importer une recherche..."), g.importPreset))
+	if len(share) > 0 {
+		shareItem := fyne.NewMenuItem(lang.L("Partager"), nil)
+		shareItem.ChildMenu = fyne.NewMenu("", share...)
+		removeItem := fyne.NewMenuItem(lang.L("Supprimer"), nil)
+		removeItem.ChildMenu = fyne.NewMenu("", remove...)
+		items = append(items, shareItem, removeItem)
+	}
+	return fyne.NewMenu(lang.L("Recherches"), items...)
+}
+
+// buildListPage construit la page liste : recherche, filtres, tri et résultats
+func (g *App) buildListPage() {
+	g.listView = newListView(g.store.Results, g.favs, func(id int) bool { return g.selectedIDs[id] })
+	g.listView.OnOpen = g.openArtist
+	g.listView.OnCheck = g.toggleSelected
+	g.listView.OnFavorite = g.toggleFavorite
+
+	g.filters = newFilterPanel(g.store)
+	g.filters.OnExportFavorites = g.exportFavorites
+	g.filters.OnImportFavorites = g.importFavorites
+
+	// Artistes cochés dans la liste : comparaison (2 à 4) et export .ics
+	g.compareBtn = widget.NewButtonWithIcon(lang.L("Comparer"), theme.ViewRestoreIcon(), func() {
+		var ids []int
+		for _, a := range g.selectedArtists() {
+			ids = append(ids, a.ID)
+		}
+		g.nav.Navigate(Route{Kind: RouteCompare, IDs: ids})
+	})
+	g.compareBtn.Disable()
+	g.icsBtn = widget.NewButtonWithIcon(".ics", theme.DocumentSaveIcon(), func() {
+		saveICS(g.win, "concerts.ics", g.selectedArtists(), g.concerts)
+	})
+	g.icsBtn.Disable()
+	statsBtn := widget.NewButtonWithIcon(lang.L("Statistiques"), theme.InfoIcon(), func() {
+		g.nav.Navigate(Route{Kind: RouteStats})
+	})
+	calendarBtn := widget.NewButtonWithIcon(lang.L("Calendrier"), theme.HistoryIcon(), func() {
+		g.nav.Navigate(Route{Kind: RouteCalendar})
+	})
+	exportBtn := widget.NewButtonWithIcon(lang.L("Exporter"), theme.DownloadIcon(), func() {
+		showExportDialog(g.win, g.results(), g.concerts)
+	})
+
+	// Barre de recherche
+	g.search = widget.NewEntryWithData(g.store.Query)
+	g.search.SetPlaceHolder(lang.L("Rechercher un artiste... (Ctrl+F)"))
+
+	// On agrandit la barre via un container
+	searchContainer := container.NewPadded(g.search)
+
+	// Recherches enregistrées : le menu est reconstruit à chaque ouverture
+	var presetsBtn *widget.Button
+	presetsBtn = widget.NewButtonWithIcon(lang.L("Recherches"), theme.ListIcon(), func() {
+		widget.ShowPopUpMenuAtRelativePosition(g.presetMenu(), g.win.Canvas(),
+			fyne.NewPos(0, presetsBtn.Size().Height), presetsBtn)
+	})
+
+	// Bouton Filtres
+	filterBtn := widget.NewButton(lang.L("Filtres (Ctrl+M)"), g.filters.Toggle)
+	filterBtn.Importance = widget.MediumImportance
+
+	settingsBtn := widget.NewButtonWithIcon("", theme.SettingsIcon(), g.openSettings)
+
+	// Affiche uniquement les favoris
+	g.favOnly = widget.NewCheckWithData(lang.L("Favoris"), g.store.FavOnly)
+
+	g.statFilterBtn = widget.NewButtonWithIcon("", theme.CancelIcon(), func() { g.setStatFilter("", nil) })
+	g.statFilterBtn.Hide()
+
+	// Sélecteur de tri (sauvegardé entre les sessions)
+	sortLabels := make([]string, len(api.SortOrders))
+	for i, o := range api.SortOrders {
+		sortLabels[i] = sortLabel(o)
+	}
+	g.sortSelect = widget.NewSelect(sortLabels, func(label string) {
+		g.store.Sort.Set(string(sortOrderFromLabel(label)))
+	})
+	g.store.Sort.AddListener(binding.NewDataListener(func() {
+		value, _ := g.store.Sort.Get()
+		order := api.SortOrder(value)
+		if g.sortSelect.Selected != sortLabel(order) {
+			g.sortSelect.SetSelected(sortLabel(order))
+		}
+		g.fyne.Preferences().SetString(prefSortOrder, value)
+	}))
+
+	// Header avec titre et compteur
+	title := widget.NewRichText(&widget.TextSegment{Text: "Groupie Tracker", Style: widget.RichTextStyle{
+		Alignment: fyne.TextAlignCenter,
+		ColorName: colorNameAccent,
+		SizeName:  sizeNameTitle,
+		TextStyle: fyne.TextStyle{Bold: true},
+	}})
+
+	resultCount := widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	g.store.Count.AddListener(binding.NewDataListener(func() {
+		n, _ := g.store.Count.Get()
+		resultCount.SetText(plural("%d artiste(s)", n))
+	}))
+
+	headerBox := container.NewVBox(
+		title,
+		resultCount,
+	)
+
+	// Search large à gauche, tri et filtre à droite
+	topBar := container.NewBorder(
+		nil, nil, nil, container.NewHBox(g.favOnly, g.sortSelect, g.listView.viewToggle, g.compareBtn, g.icsBtn, exportBtn, statsBtn, calendarBtn, presetsBtn, filterBtn, settingsBtn),
+		searchContainer,
+	)
+
+	g.listPage = container.NewBorder(
+		container.NewVBox(
+			headerBox,
+			widget.NewSeparator(),
+			container.NewPadded(topBar),
+			container.NewHBox(g.statFilterBtn),
+			g.filters.content,
+		),
+		nil, nil, nil,
+		g.listView.content,
+	)
+
+	// La route courante garde le texte de recherche
+	g.store.Query.AddListener(binding.NewDataListener(func() {
+		if g.nav.Current().Kind == RouteList {
+			query, _ := g.store.Query.Get()
+			g.nav.Replace(Route{Kind: RouteList, Query: query})
+		}
+	}))
+	// Le calendrier déjà construit suit les résultats
+	g.store.Results.AddListener(binding.NewDataListener(func() {
+		if g.calendar != nil {
+			g.calendar.Refresh()
+		}
+	}))
+}
+
+// showList affiche la page liste avec la recherche donnée
+func (g *App) showList(query string) {
+	g.details = nil
+	g.store.Query.Set(query)
+	g.win.SetContent(g.listPage)
+}
+
+// showDetails affiche la page détails d'un artiste
+func (g *App) showDetails(artist api.Artist) {
+	// nil si les relations de l'artiste ne sont pas dans l'index
+	concerts, ok := g.concerts[artist.ID]
+	if ok && concerts == nil {
+		concerts = []api.Concert{}
+	}
+	v := newDetailsView(artist, concerts, g.favs.Has(artist.ID))
+	v.OnMap = func(place string) {
+		g.nav.Navigate(Route{Kind: RouteMap, ID: artist.ID, Place: place})
+	}
+	v.OnFavorite = func() { g.toggleFavorite(artist.ID) }
+	v.OnExport = func() {
+		saveICS(g.win, artist.Name+".ics", []api.Artist{artist}, g.concerts)
+	}
+	v.OnBack = g.goBack
+
+	g.details = v
+	g.win.SetContent(v.content)
+}
+
+// showMap affiche la carte des concerts d'un artiste
+func (g *App) showMap(artist api.Artist, focus string) {
+	place, _ := g.store.Place.Get()
+	v := newMapView(artist, focus, place)
+	v.OnBack = g.goBack
+
+	g.details = nil
+	g.win.SetContent(v.content)
+}
+
+// registerRoutes associe chaque type d'écran à son affichage
+func (g *App) registerRoutes() {
+	g.nav.Register(RouteList, func(r Route) bool {
+		g.showList(r.Query)
+		return true
+	})
+	g.nav.Register(RouteArtist, func(r Route) bool {
+		artist, ok := g.byID[r.ID]
+		if ok {
+			g.showDetails(artist)
+		}
+		return ok
+	})
+	g.nav.Register(RouteCompare, func(r Route) bool {
+		if len(r.IDs) < minCompared || len(r.IDs) > maxCompared {
+			return false
+		}
+		var compared []api.Artist
+		for _, id := range r.IDs {
+			artist, ok := g.byID[id]
+			if !ok {
+				return false
+			}
+			compared = append(compared, artist)
+		}
+		g.details = nil
+		g.win.SetContent(newCompareView(compared, g.concerts, g.goBack))
+		return true
+	})
+	// Calendrier construit à la première ouverture, il garde ensuite le mois affiché
+	g.nav.Register(RouteCalendar, func(r Route) bool {
+		if g.calendar == nil {
+			names := make(map[int]string, len(g.artists))
+			for _, a := range g.artists {
+				names[a.ID] = a.Name
+			}
+			g.calendar = newCalendarView(g.concerts, names,
+				g.results,
+				func(id int) { g.nav.Navigate(Route{Kind: RouteArtist, ID: id}) },
+				g.goBack,
+			)
+		}
+		g.details = nil
+		g.win.SetContent(g.calendar.content)
+		return true
+	})
+	// Statistiques calculées à la première ouverture du tableau de bord
+	g.nav.Register(RouteStats, func(r Route) bool {
+		if g.stats == nil {
+			computed := api.ComputeStats(g.artists, g.concerts)
+			g.stats = &computed
+		}
+		g.details = nil
+		g.win.SetContent(newStatsView(*g.stats, g.applyStatFilter, g.goBack))
+		return true
+	})
+	g.nav.Register(RouteMap, func(r Route) bool {
+		artist, ok := g.byID[r.ID]
+		if ok {
+			g.showMap(artist, r.Place)
+		}
+		return ok
+	})
+}
+
+// applyStatFilter applique à la liste le filtre d'une barre cliquée dans les statistiques
+func (g *App) applyStatFilter(chart statChart, b api.Bucket) {
+	switch chart {
+	case chartDecades:
+		g.setStatFilter(fmt.Sprintf(lang.L("Création : %s"), b.Label), func(a api.Artist) bool { return a.CreationDate/10*10 == b.Key })
+	case chartAlbumGaps:
+		g.setStatFilter(fmt.Sprintf(lang.L("Premier album après %s"), b.Label), func(a api.Artist) bool {
+			gap, ok := api.AlbumGap(a)
+			return ok && gap == b.Key
+		})
+	case chartMembers:
+		g.setStatFilter(b.Label, func(a api.Artist) bool { return len(a.Members) == b.Key })
+	case chartCities, chartCountries:
+		g.setPeriod("", "", b.Label)
+	case chartYears:
+		g.setPeriod(b.Label, b.Label, "")
+	}
+	query, _ := g.store.Query.Get()
+	g.nav.Navigate(Route{Kind: RouteList, Query: query})
+}
+
+// setPeriod remplit la période de concerts et affiche le menu des filtres
+func (g *App) setPeriod(from, to, place string) {
+	g.store.From.Set(from)
+	g.store.To.Set(to)
+	g.store.Place.Set(place)
+	g.filters.content.Show()
+}
+
+// onTypedKey gère les touches Échap et Entrée
+func (g *App) onTypedKey(key *fyne.KeyEvent) {
+	switch key.Name {
+	case fyne.KeyEscape:
+		// Échap: Retour à l'écran précédent
+		if g.nav.Current().Kind != RouteList {
+			g.goBack()
+		}
+
+	case fyne.KeyReturn, fyne.KeyEnter:
+		// Entrée: Ouvrir l'artiste sélectionné, sinon le premier résultat
+		results := g.results()
+		if g.nav.Current().Kind == RouteList && len(results) > 0 {
+			artist, ok := g.listView.Selected()
+			if !ok {
+				artist = results[0]
+			}
+			g.openArtist(artist)
+		}
+	}
+}
+
+// registerShortcuts installe les raccourcis clavier de la fenêtre
+func (g *App) registerShortcuts() {
+	c := g.win.Canvas()
+	c.SetOnTypedKey(g.onTypedKey)
+
+	c.AddShortcut(&fyne.ShortcutCopy{}, func(shortcut fyne.Shortcut) {})
+
+	// Ctrl+F: Focus sur la recherche
+	ctrlF := &desktop.CustomShortcut{
+		KeyName:  fyne.KeyF,
+		Modifier: fyne.KeyModifierControl,
+	}
+	c.AddShortcut(ctrlF, func(shortcut fyne.Shortcut) {
+		if g.nav.Current().Kind == RouteList {
+			c.Focus(g.search)
+		}
+	})
+
+	// Ctrl+M: Afficher/masquer les filtres
+	ctrlM := &desktop.CustomShortcut{
+		KeyName:  fyne.KeyM,
+		Modifier: fyne.KeyModifierControl,
+	}
+	c.AddShortcut(ctrlM, func(shortcut fyne.Shortcut) {
+		if g.nav.Current().Kind == RouteList {
+			g.filters.Toggle()
+		}
+	})
+
+	// Ctrl+Q: Quitter l'application
+	ctrlQ := &desktop.CustomShortcut{
+		KeyName:  fyne.KeyQ,
+		Modifier: fyne.KeyModifierControl,
+	}
+	c.AddShortcut(ctrlQ, func(shortcut fyne.Shortcut) {
+		g.fyne.Quit()
+	})
+
+	// Alt+Gauche / Alt+Droite: Écran précédent / suivant
+	altLeft := &desktop.CustomShortcut{
+		KeyName:  fyne.KeyLeft,
+		Modifier: fyne.KeyModifierAlt,
+	}
+	c.AddShortcut(altLeft, func(shortcut fyne.Shortcut) {
+		g.nav.Back()
+	})
+	altRight := &desktop.CustomShortcut{
+		KeyName:  fyne.KeyRight,
+		Modifier: fyne.KeyModifierAlt,
+	}
+	c.AddShortcut(altRight, func(shortcut fyne.Shortcut) {
+		g.nav.Forward()
+	})
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+
+	"groupie/fakeapi"
+	"groupie/imagecache"
+	api "groupie/models"
+)
+
+// newTestApp construit l'application sur le pilote de test de Fyne, avec les données de l'API factice
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+	ts := httptest.NewServer(fakeapi.New(fakeapi.Options{}))
+	t.Cleanup(ts.Close)
+	api.SetBaseURL(ts.URL + "/api")
+	t.Cleanup(func() { api.SetBaseURL(api.DefaultBaseURL) })
+
+	// Textes attendus par les tests : l'interface en français, quelle que soit la langue du système
+	setLanguage("fr")
+
+	// Photos dans un dossier jetable : les tests ne touchent pas au cache de l'utilisateur
+	dir, err := os.MkdirTemp("", "groupie-images")
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := images
+	images = imagecache.New(dir, 2, imageClient)
+	t.Cleanup(func() {
+		images = previous
+		os.RemoveAll(dir)
+	})
+
+	artists, err := api.FetchArtists()
+	if err != nil {
+		t.Fatal(err)
+	}
+	relations, err := api.FetchRelationIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := NewApp(test.NewTempApp(t), artists, api.BuildConcertIndex(relations))
+	g.Window().Resize(fyne.NewSize(1200, 900))
+	return g
+}
+
+// renderedText renvoie les textes affichés dans la fenêtre, séparés par des retours à la ligne
+func renderedText(g *App) string {
+	var texts []string
+	for _, o := range test.LaidOutObjects(g.Window().Content()) {
+		if text, ok := o.(*canvas.Text); ok && o.Visible() && text.Text != "" {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// names renvoie les noms des artistes de la liste filtrée
+func names(g *App) []string {
+	res := make([]string, len(g.results()))
+	for i, a := range g.results() {
+		res[i] = a.Name
+	}
+	return res
+}
+
+// tapCheck coche ou décoche une case en cliquant au milieu de sa zone active
+func tapCheck(c *widget.Check) {
+	test.TapAt(c, fyne.NewPos(c.MinSize().Width/2, c.Size().Height/2))
+}
+
+// pressKey envoie une touche au gestionnaire clavier de la fenêtre
+func pressKey(g *App, key fyne.KeyName) {
+	g.Window().Canvas().OnTypedKey()(&fyne.KeyEvent{Name: key})
+}
+
+func TestSearchFiltersList(t *testing.T) {
+	g := newTestApp(t)
+	if len(g.results()) != 5 {
+		t.Fatalf("%d artistes au démarrage, attendu 5", len(g.results()))
+	}
+
+	test.Type(g.search, "pink")
+
+	if got := names(g); len(got) != 1 || got[0] != "Pink Floyd" {
+		t.Errorf("résultats = %v, attendu [Pink Floyd]", got)
+	}
+	shown := renderedText(g)
+	if !strings.Contains(shown, "1 artiste\n") {
+		t.Errorf("compteur absent :\n%s", shown)
+	}
+	if !strings.Contains(shown, "Pink Floyd") || strings.Contains(shown, "Queen") {
+		t.Errorf("liste affichée :\n%s", shown)
+	}
+	if r := g.nav.Current(); r.Kind != RouteList || r.Query != "pink" {
+		t.Errorf("route = %s, attendu groupie://list?q=pink", r)
+	}
+}
+
+func TestFilterChecksRestrictFields(t *testing.T) {
+	g := newTestApp(t)
+
+	// Sans filtre coché, la recherche porte aussi sur les membres
+	test.Type(g.search, "freddie")
+	if got := names(g); len(got) != 1 || got[0] != "Queen" {
+		t.Fatalf("résultats = %v, attendu [Queen]", got)
+	}
+
+	// Filtre « Artistes » seul : les membres sont ignorés
+	tapCheck(g.filters.fields[api.FieldName])
+	if got := names(g); len(got) != 0 {
+		t.Errorf("filtre Artistes : résultats = %v, attendu aucun", got)
+	}
+
+	// Filtre « Création » : 1965 correspond à Pink Floyd et Scorpions
+	tapCheck(g.filters.fields[api.FieldName])
+	tapCheck(g.filters.fields[api.FieldCreated])
+	g.search.SetText("1965")
+	if got := names(g); len(got) != 2 {
+		t.Errorf("filtre Création : résultats = %v, attendu 2 artistes", got)
+	}
+}
+
+func TestFilterChecksShowFacets(t *testing.T) {
+	g := newTestApp(t)
+	if text := g.filters.fields[api.FieldName].Text; text != "Artistes (5)" {
+		t.Errorf("case Artistes au démarrage = %q", text)
+	}
+
+	// Chaque case compte les artistes que trouverait son champ seul
+	test.Type(g.search, "1965")
+	if text := g.filters.fields[api.FieldCreated].Text; text != "Création (2)" {
+		t.Errorf("case Création = %q, attendu « Création (2) »", text)
+	}
+	if text := g.filters.fields[api.FieldName].Text; text != "Artistes (0)" {
+		t.Errorf("case Artistes = %q, attendu « Artistes (0) »", text)
+	}
+
+	// Appliquer un état complet met à jour les widgets liés
+	g.store.Apply(FilterState{Query: "queen", Sort: api.SortNameDesc})
+	if g.search.Text != "queen" {
+		t.Errorf("recherche = %q après Apply", g.search.Text)
+	}
+	if got := names(g); len(got) != 1 || got[0] != "Queen" {
+		t.Errorf("résultats = %v après Apply", got)
+	}
+	if g.sortSelect.Selected != sortLabel(api.SortNameDesc) {
+		t.Errorf("tri affiché = %q", g.sortSelect.Selected)
+	}
+}
+
+func TestPeriodFilterShowsConcerts(t *testing.T) {
+	g := newTestApp(t)
+
+	g.filters.place.SetText("osaka")
+
+	if got := names(g); len(got) != 1 || got[0] != "Queen" {
+		t.Fatalf("résultats = %v, attendu [Queen]", got)
+	}
+	if shown := renderedText(g); !strings.Contains(shown, "Osaka") {
+		t.Errorf("concert d'Osaka absent de la liste :\n%s", shown)
+	}
+}
+
+func TestInvalidPeriodShowsError(t *testing.T) {
+	g := newTestApp(t)
+
+	// La borne invalide est ignorée par la recherche, mais signalée
+	g.filters.from.SetText("2019x")
+	if got := names(g); len(got) != 5 {
+		t.Errorf("résultats = %v, attendu les 5 artistes", got)
+	}
+	if g.filters.from.Validate() == nil || !g.filters.periodError.Visible() {
+		t.Error("la date invalide n'est pas signalée")
+	}
+
+	g.filters.from.SetText("2019")
+	if g.filters.from.Validate() != nil || g.filters.periodError.Visible() {
+		t.Error("l'erreur reste affichée pour une date valide")
+	}
+}
+
+func TestFavoritesOnly(t *testing.T) {
+	g := newTestApp(t)
+	g.favs.Toggle(4)
+
+	tapCheck(g.favOnly)
+	if got := names(g); len(got) != 1 || got[0] != "Scorpions" {
+		t.Errorf("favoris = %v, attendu [Scorpions]", got)
+	}
+
+	tapCheck(g.favOnly)
+	if len(g.results()) != 5 {
+		t.Errorf("%d artistes après avoir décoché Favoris, attendu 5", len(g.results()))
+	}
+}
+
+func TestSelectRowOpensDetails(t *testing.T) {
+	g := newTestApp(t)
+	test.Type(g.search, "queen")
+
+	g.listView.list.Select(0)
+
+	if r := g.nav.Current(); r.Kind != RouteArtist || r.ID != 1 {
+		t.Fatalf("route = %s, attendu groupie://artist/1", r)
+	}
+	shown := renderedText(g)
+	for _, want := range []string{"Queen", "14/12/1973", "Tournée :"} {
+		if !strings.Contains(shown, want) {
+			t.Errorf("%q absent de la page détails :\n%s", want, shown)
+		}
+	}
+
+	// Lieux et dates chargés en arrière-plan
+	waitFor(t, "lieux de Queen", func() bool { return strings.Contains(renderedText(g), "Osaka-Japan") })
+}
+
+func TestEscapeReturnsToList(t *testing.T) {
+	g := newTestApp(t)
+	test.Type(g.search, "soja")
+	g.listView.list.Select(0)
+
+	pressKey(g, fyne.KeyEscape)
+
+	if r := g.nav.Current(); r.Kind != RouteList || r.Query != "soja" {
+		t.Errorf("route = %s, attendu groupie://list?q=soja", r)
+	}
+	if g.Window().Content() != g.listPage {
+		t.Error("la page liste n'est pas affichée")
+	}
+	// La ligne sélectionnée est conservée pour Entrée
+	if g.listView.selected != 0 {
+		t.Errorf("ligne sélectionnée = %d, attendu 0", g.listView.selected)
+	}
+
+	// Échap sur la liste ne fait rien
+	pressKey(g, fyne.KeyEscape)
+	if g.nav.Current().Kind != RouteList {
+		t.Errorf("route = %s après Échap sur la liste", g.nav.Current())
+	}
+}
+
+func TestEnterOpensArtist(t *testing.T) {
+	g := newTestApp(t)
+
+	// Sans sélection, Entrée ouvre le premier résultat
+	test.Type(g.search, "scorp")
+	pressKey(g, fyne.KeyReturn)
+	if r := g.nav.Current(); r.Kind != RouteArtist || r.ID != 4 {
+		t.Fatalf("route = %s, attendu groupie://artist/4", r)
+	}
+	if shown := renderedText(g); !strings.Contains(shown, "Scorpions") {
+		t.Errorf("page détails :\n%s", shown)
+	}
+
+	// Avec une ligne sélectionnée, Entrée ouvre cette ligne
+	pressKey(g, fyne.KeyEscape)
+	g.search.SetText("")
+	g.listView.list.Select(2)
+	want := g.results()[2].ID
+	pressKey(g, fyne.KeyEscape)
+	pressKey(g, fyne.KeyEnter)
+	if r := g.nav.Current(); r.Kind != RouteArtist || r.ID != want {
+		t.Errorf("route = %s, attendu groupie://artist/%d", r, want)
+	}
+}
+
+func TestDeepLink(t *testing.T) {
+	g := newTestApp(t)
+
+	route, err := ParseRoute("groupie://compare/1,3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.Navigate(route) {
+		t.Fatal("lien de comparaison refusé")
+	}
+	if g.Navigate(Route{Kind: RouteArtist, ID: 999}) {
+		t.Error("artiste inconnu accepté")
+	}
+	if r := g.nav.Current(); r.Kind != RouteCompare {
+		t.Errorf("route = %s, attendu la comparaison", r)
+	}
+}
+
+func TestDetailsFavoriteUpdatesList(t *testing.T) {
+	g := newTestApp(t)
+	tapCheck(g.favOnly)
+	if len(g.results()) != 0 {
+		t.Fatalf("favoris au démarrage : %v", names(g))
+	}
+
+	g.Navigate(Route{Kind: RouteArtist, ID: 3})
+	test.Tap(g.details.favBtn)
+	if !g.favs.Has(3) || g.details.favBtn.Icon != starIcon {
+		t.Fatal("l'étoile de la page détails n'a pas ajouté le favori")
+	}
+
+	// La liste a suivi sans attendre le retour
+	pressKey(g, fyne.KeyEscape)
+	if got := names(g); len(got) != 1 || got[0] != "Pink Floyd" {
+		t.Errorf("favoris = %v, attendu [Pink Floyd]", got)
+	}
+}
+
+func TestApplyPresetFromMenu(t *testing.T) {
+	g := newTestApp(t)
+	g.presets.Save(Preset{Name: "Création 1965", State: FilterState{
+		Query:  "1965",
+		Fields: map[string]bool{api.FieldCreated: true},
+	}})
+	g.openArtist(g.results()[0])
+
+	// Le menu propose la recherche enregistrée ; l'appliquer revient à la liste filtrée
+	menu := g.presetMenu()
+	if menu.Items[0].Label != "Création 1965" {
+		t.Fatalf("menu = %q", menu.Items[0].Label)
+	}
+	menu.Items[0].Action()
+	if g.nav.Current().Kind != RouteList {
+		t.Errorf("route = %s, attendu la liste", g.nav.Current())
+	}
+	if g.search.Text != "1965" || !g.filters.fields[api.FieldCreated].Checked {
+		t.Errorf("recherche %q, case Création %v", g.search.Text, g.filters.fields[api.FieldCreated].Checked)
+	}
+	if got := names(g); len(got) != 2 {
+		t.Errorf("résultats = %v, attendu 2 artistes", got)
+	}
+}
+
+func TestApplyPresetClearsStatFilter(t *testing.T) {
+	g := newTestApp(t)
+	g.setStatFilter("Aucun", func(api.Artist) bool { return false })
+	if got := names(g); len(got) != 0 {
+		t.Fatalf("résultats = %v avec le filtre des statistiques", got)
+	}
+
+	g.applyPreset(Preset{Name: "Queen", State: FilterState{Query: "queen"}})
+	if got := names(g); len(got) != 1 || got[0] != "Queen" {
+		t.Errorf("résultats = %v, attendu [Queen]", got)
+	}
+	if g.statFilterBtn.Visible() {
+		t.Error("le filtre des statistiques est toujours affiché")
+	}
+}
+
+func TestSavePresetAsksBeforeReplacing(t *testing.T) {
+	g := newTestApp(t)
+	g.presets.Save(Preset{Name: "Ma recherche", State: FilterState{Query: "queen"}})
+	test.Type(g.search, "floyd")
+
+	// Le nom est pris : rien n'est remplacé avant la confirmation
+	g.savePresetAs("Ma recherche")
+	if p, _ := g.presets.Get("Ma recherche"); p.State.Query != "queen" {
+		t.Fatalf("recherche remplacée sans confirmation : %+v", p)
+	}
+	confirm := g.Window().Canvas().Overlays().Top()
+	if confirm == nil {
+		t.Fatal("aucune demande de confirmation")
+	}
+	for _, o := range test.LaidOutObjects(confirm) {
+		if b, ok := o.(*widget.Button); ok && b.Importance == widget.HighImportance {
+			test.Tap(b)
+		}
+	}
+	if p, _ := g.presets.Get("Ma recherche"); p.State.Query != "floyd" {
+		t.Errorf("recherche après confirmation = %+v", p)
+	}
+}
+
+func TestSettingsLanguageRebuildsViews(t *testing.T) {
+	g := newTestApp(t)
+	t.Cleanup(func() { setLanguage("fr") })
+	test.Type(g.search, "queen")
+
+	old := loadSettings(g.fyne.Preferences())
+	s := old
+	s.Language = "en"
+	s.Sort = api.SortNameDesc
+	g.updateSettings(old, s)
+
+	// Les vues sont reconstruites en anglais, la recherche en cours est conservée
+	if g.Window().Content() != g.listPage {
+		t.Fatal("la page liste n'est pas affichée")
+	}
+	if g.search.Text != "queen" || g.search.PlaceHolder != "Search for an artist... (Ctrl+F)" {
+		t.Errorf("recherche %q, aide %q", g.search.Text, g.search.PlaceHolder)
+	}
+	if shown := renderedText(g); !strings.Contains(shown, "1 artist\n") {
+		t.Errorf("compteur absent :\n%s", shown)
+	}
+	if g.sortSelect.Selected != sortLabel(api.SortNameDesc) {
+		t.Errorf("tri affiché = %q", g.sortSelect.Selected)
+	}
+}
+
+func TestSettingsKeepWorkingAPIURL(t *testing.T) {
+	g := newTestApp(t)
+	prefs := g.fyne.Preferences()
+	working := api.BaseURL()
+	broken := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(broken.Close)
+
+	// Une URL où les artistes ne se chargent pas n'est ni gardée ni sauvegardée
+	old := loadSettings(prefs)
+	s := old
+	s.APIURL = broken.URL + "/api"
+	g.updateSettings(old, s)
+	waitFor(t, "retour à l'URL précédente", func() bool { return api.BaseURL() == working })
+	if url := prefs.String(prefAPIURL); url == s.APIURL {
+		t.Errorf("URL en erreur sauvegardée : %q", url)
+	}
+	if len(g.results()) != 5 {
+		t.Errorf("%d artistes après l'échec, attendu 5", len(g.results()))
+	}
+
+	// Une URL qui fonctionne est sauvegardée une fois les artistes chargés
+	s.APIURL = working
+	g.updateSettings(old, s)
+	waitFor(t, "URL sauvegardée", func() bool { return prefs.String(prefAPIURL) == working })
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	api "groupie/models"
+)
+
+// Jours de la semaine, en commençant par lundi (clés des catalogues)
+var weekdayNames = [...]string{"Lun", "Mar", "Mer", "Jeu", "Ven", "Sam", "Dim"}
+
+// calendarView affiche les concerts de tous les artistes sur une grille mensuelle
+type calendarView struct {
+	concerts map[int][]api.Concert
+	names    map[int]string
+	results  func() []api.Artist // Résultats de la recherche courante
+	onSelect func(artistID int)
+
+	year  int
+	month time.Month
+
+	title       *widget.Label
+	yearSelect  *widget.Select
+	onlyResults *widget.Check
+	grid        *fyne.Container
+	content     fyne.CanvasObject
+}
+
+// newCalendarView crée le calendrier des concerts
+// Il s'ouvre sur le mois du concert le plus récent
+func newCalendarView(concerts map[int][]api.Concert, names map[int]string, results func() []api.Artist, onSelect func(artistID int), onBack func()) *calendarView {
+	c := &calendarView{
+		concerts: concerts,
+		names:    names,
+		results:  results,
+		onSelect: onSelect,
+		year:     time.Now().Year(),
+		month:    time.Now().Month(),
+	}
+	if latest := api.LatestConcert(concerts); !latest.IsZero() {
+		c.year, c.month = latest.Year(), latest.Month()
+	}
+
+	c.title = widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+
+	// Saut direct à une année
+	var years []string
+	for _, y := range api.ConcertYears(concerts) {
+		years = append(years, strconv.Itoa(y))
+	}
+	c.yearSelect = widget.NewSelect(years, func(s string) {
+		if y, err := strconv.Atoi(s); err == nil && y != c.year {
+			c.year = y
+			c.Refresh()
+		}
+	})
+	c.yearSelect.PlaceHolder = lang.L("Année")
+
+	c.onlyResults = widget.NewCheck(lang.L("Résultats de recherche uniquement"), func(bool) { c.Refresh() })
+
+	prev := widget.NewButtonWithIcon("", theme.NavigateBackIcon(), func() { c.shift(-1) })
+	next := widget.NewButtonWithIcon("", theme.NavigateNextIcon(), func() { c.shift(1) })
+	backBtn := widget.NewButton(lang.L("Retour (Échap)"), onBack)
+
+	c.grid = container.NewGridWithColumns(7)
+
+	header := container.NewVBox(
+		container.NewBorder(nil, nil, backBtn, nil, widget.NewLabelWithStyle(lang.L("Calendrier des concerts"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})),
+		container.NewBorder(nil, nil, container.NewHBox(prev, next), container.NewHBox(c.onlyResults, c.yearSelect), c.title),
+	)
+	c.content = container.NewBorder(header, nil, nil, nil, container.NewPadded(container.NewVScroll(c.grid)))
+	c.Refresh()
+	return c
+}
+
+// shift avance ou recule de n mois
+func (c *calendarView) shift(n int) {
+	t := time.Date(c.year, c.month, 1, 0, 0, 0, 0, time.UTC).AddDate(0, n, 0)
+	c.year, c.month = t.Year(), t.Month()
+	c.Refresh()
+}
+
+// Refresh reconstruit la grille du mois affiché
+// À appeler aussi quand les résultats de recherche changent
+func (c *calendarView) Refresh() {
+	c.title.SetText(fmt.Sprintf("%s %d", lang.L(api.MonthNames[c.month-1]), c.year))
+	if c.yearSelect.Selected != strconv.Itoa(c.year) {
+		c.yearSelect.SetSelected(strconv.Itoa(c.year))
+	}
+
+	var keep func(int) bool
+	if c.onlyResults.Checked {
+		ids := map[int]bool{}
+		for _, a := range c.results() {
+			ids[a.ID] = true
+		}
+		keep = func(id int) bool { return ids[id] }
+	}
+	days := api.ConcertsInMonth(c.concerts, c.year, c.month, keep)
+
+	cells := make([]fyne.CanvasObject, 0, 42)
+	for _, d := range weekdayNames {
+		cells = append(cells, widget.NewLabelWithStyle(lang.L(d), fyne.TextAlignCenter, fyne.TextStyle{Bold: true}))
+	}
+
+	// Cases vides avant le premier jour (la semaine commence le lundi)
+	first := time.Date(c.year, c.month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(first.Weekday()) + 6) % 7
+	for i := 0; i < offset; i++ {
+		cells = append(cells, widget.NewLabel(""))
+	}
+
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+	for day := 1; day <= daysInMonth; day++ {
+		cell := container.NewVBox(widget.NewLabelWithStyle(strconv.Itoa(day), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		for _, concert := range days[day] {
+			id := concert.ArtistID
+			entry := widget.NewButton(c.names[id], func() { c.onSelect(id) })
+			entry.Importance = widget.LowImportance
+			entry.Alignment = widget.ButtonAlignLeading
+			cell.Add(entry)
+		}
+		cells = append(cells, createCard(cell))
+	}
+
+	c.grid.Objects = cells
+	c.grid.Refresh()
+}
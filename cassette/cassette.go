@@ -0,0 +1,178 @@
+// Package cassette enregistre les échanges HTTP dans un fichier pour les rejouer à l'identique
+// Une cassette jointe à un rapport de bug permet de reproduire la session sans réseau
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// Mode choisit entre l'enregistrement et la relecture
+type Mode int
+
+const (
+	Record Mode = iota // Les requêtes partent sur le réseau et sont enregistrées
+	Replay             // Les réponses viennent de la cassette, sans réseau
+)
+
+// Interaction est un échange enregistré : une requête et sa réponse
+type Interaction struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"` // Encodé en base64 dans le fichier
+}
+
+// file est le contenu d'un fichier cassette
+type file struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder est un http.RoundTripper qui enregistre ou rejoue les échanges
+type Recorder struct {
+	mode Mode
+	path string
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	played       map[string]int // Nombre de réponses déjà rejouées par requête
+}
+
+// New ouvre une cassette
+// En relecture, le fichier doit exister ; en enregistrement, il sera écrit par Save
+// next transporte les requêtes enregistrées (http.DefaultTransport si nil)
+func New(path string, mode Mode, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	r := &Recorder{mode: mode, path: path, next: next, played: map[string]int{}}
+	if mode == Replay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var f file
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("cassette %s invalide : %w", path, err)
+		}
+		r.interactions = f.Interactions
+	}
+	return r, nil
+}
+
+// key identifie une requête : méthode et URL complète
+func key(method, url string) string {
+	return method + " " + url
+}
+
+// RoundTrip enregistre ou rejoue une requête
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == Replay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Status: resp.StatusCode,
+		Header: header,
+		Body:   body,
+	})
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// replay renvoie les réponses d'une même requête dans l'ordre de l'enregistrement
+// Une fois toutes rejouées, la dernière est renvoyée à nouveau
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	k := key(req.Method, req.URL.String())
+
+	r.mu.Lock()
+	var matches []Interaction
+	for _, in := range r.interactions {
+		if key(in.Method, in.URL) == k {
+			matches = append(matches, in)
+		}
+	}
+	if len(matches) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("cassette %s : aucun enregistrement pour %s", r.path, k)
+	}
+	n := r.played[k]
+	r.played[k]++
+	r.mu.Unlock()
+
+	in := matches[min(n, len(matches)-1)]
+	header := in.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(in.Status) + " " + http.StatusText(in.Status),
+		StatusCode:    in.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(in.Body)),
+		ContentLength: int64(len(in.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Interactions renvoie les échanges enregistrés ou chargés
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save écrit les échanges enregistrés dans le fichier de la cassette
+// Sans effet en relecture
+func (r *Recorder) Save() error {
+	if r.mode != Record {
+		return nil
+	}
+	data, err := json.MarshalIndent(file{Interactions: r.Interactions()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(r.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	// Écriture dans un fichier temporaire puis renommage : pas de cassette à moitié écrite
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.path)
+}
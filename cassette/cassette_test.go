@@ -0,0 +1,129 @@
+package cassette_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"groupie/cassette"
+	"groupie/fakeapi"
+)
+
+// fetch envoie une requête GET avec le transport donné et renvoie le corps
+func fetch(t *testing.T, rt http.RoundTripper, url string) (int, []byte) {
+	t.Helper()
+	resp, err := (&http.Client{Transport: rt}).Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	ts := httptest.NewServer(fakeapi.New(fakeapi.Options{}))
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	rec, err := cassette.New(path, cassette.Record, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	urls := []string{ts.URL + "/api/artists", ts.URL + "/api/relation/1", ts.URL + "/api/images/queen.jpeg", ts.URL + "/api/artists/999"}
+	recorded := map[string][]byte{}
+	statuses := map[string]int{}
+	for _, u := range urls {
+		statuses[u], recorded[u] = fetch(t, rec, u)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	ts.Close() // Plus de serveur : la relecture ne doit pas toucher au réseau
+
+	replay, err := cassette.New(path, cassette.Replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range urls {
+		status, body := fetch(t, replay, u)
+		if status != statuses[u] {
+			t.Errorf("%s : status %d, enregistré %d", u, status, statuses[u])
+		}
+		if !bytes.Equal(body, recorded[u]) {
+			t.Errorf("%s : corps rejoué différent de l'enregistrement", u)
+		}
+	}
+}
+
+func TestReplayUnknownRequest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.json")
+	rec, _ := cassette.New(path, cassette.Record, nil)
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := cassette.New(path, cassette.Replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = (&http.Client{Transport: replay}).Get("http://example.invalid/api/artists")
+	if err == nil || !strings.Contains(err.Error(), "aucun enregistrement") {
+		t.Errorf("erreur = %v, attendu « aucun enregistrement »", err)
+	}
+}
+
+func TestReplayKeepsOrderOfRepeatedRequests(t *testing.T) {
+	fake := fakeapi.New(fakeapi.Options{})
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+	path := filepath.Join(t.TempDir(), "repeat.json")
+	url := ts.URL + "/api/dates/1"
+
+	// Première réponse en erreur, seconde correcte
+	rec, _ := cassette.New(path, cassette.Record, nil)
+	fake.SetOptions(fakeapi.Options{Fail: 1})
+	fetch(t, rec, url)
+	fake.SetOptions(fakeapi.Options{})
+	fetch(t, rec, url)
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	replay, _ := cassette.New(path, cassette.Replay, nil)
+	for i, want := range []int{500, 200, 200} {
+		if status, _ := fetch(t, replay, url); status != want {
+			t.Errorf("relecture %d : status %d, attendu %d", i+1, status, want)
+		}
+	}
+}
+
+// failingTransport échoue toujours, comme un réseau coupé
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("réseau coupé")
+}
+
+func TestRecordDoesNotKeepFailedRequests(t *testing.T) {
+	rec, _ := cassette.New(filepath.Join(t.TempDir(), "offline.json"), cassette.Record, failingTransport{})
+	if _, err := (&http.Client{Transport: rec}).Get("http://example.invalid/"); err == nil {
+		t.Fatal("erreur réseau attendue")
+	}
+	if n := len(rec.Interactions()); n != 0 {
+		t.Errorf("%d échange(s) enregistré(s), attendu 0", n)
+	}
+}
+
+func TestReplayMissingFile(t *testing.T) {
+	if _, err := cassette.New(filepath.Join(t.TempDir(), "absent.json"), cassette.Replay, nil); err == nil {
+		t.Error("erreur attendue pour une cassette absente")
+	}
+}
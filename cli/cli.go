@@ -0,0 +1,548 @@
+// Package cli est la ligne de commande de Groupie Tracker : lister, chercher, exporter et servir
+// les artistes sans fenêtre. Il ne dépend pas de Fyne
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"groupie/fakeapi"
+	"groupie/geo"
+	api "groupie/models"
+	"groupie/web"
+)
+
+// usageError est une erreur de syntaxe de la ligne de commande (code de sortie 2)
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+// command est une sous-commande utilisable sans fenêtre
+type command struct {
+	usage string // Syntaxe, ex: "show <nom|id>"
+	help  string // Description courte
+	run   func(args []string, out io.Writer) error
+}
+
+// commands associe chaque sous-commande à son exécution
+// Rempli dans init : la commande help a besoin de la liste complète
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"list":     {"list [--sort ordre] [--format f] [--columns c]", "Liste les artistes", runList},
+		"show":     {"show <nom|id> [--format f]", "Affiche la fiche d'un artiste et ses concerts", runShow},
+		"concerts": {"concerts [--artist a] [--city v] [--country p] [--year a] [--from d] [--to d] [--format f]", "Liste les concerts, tous artistes confondus", runConcerts},
+		"search":   {"search <requête> [--sort ordre] [--format f] [--columns c]", "Recherche des artistes, ex: member:freddie location:\"new york\"", runSearch},
+		"serve":    {"serve [--addr :8080]", "Sert le tracker en pages HTML, et l'API REST sous /v1", runServe},
+		"fake-api": {"fake-api [--addr :8081] [--latency d] [--fail p] [--truncate p] [--malformed p] [--seed n]", "Sert une fausse API Groupie Trackers, hors ligne", runFakeAPI},
+		"help":     {"help", "Affiche cette aide", runHelp},
+	}
+}
+
+// IsCommand indique si l'argument est une sous-commande (et non un lien groupie://)
+func IsCommand(arg string) bool {
+	switch arg {
+	case "-h", "-help", "--help":
+		return true
+	}
+	_, ok := commands[arg]
+	return ok
+}
+
+// Run exécute une sous-commande, ex: ["list", "--sort", "created"]
+// La sortie est écrite dans out, les erreurs sont renvoyées
+func Run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return runHelp(nil, out)
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		if IsCommand(args[0]) {
+			return runHelp(nil, out)
+		}
+		return usageError{fmt.Sprintf("commande inconnue %q (voir groupie help)", args[0])}
+	}
+	return cmd.run(args[1:], out)
+}
+
+func runHelp(args []string, out io.Writer) error {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(out, "Utilisation : groupie [commande] [options]")
+	fmt.Fprintln(out, "Sans commande, ou avec un lien groupie://, l'interface graphique s'ouvre.")
+	fmt.Fprintln(out, "groupie-cli accepte les mêmes commandes, sans interface graphique.")
+	fmt.Fprintln(out)
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(tw, "  %s\t%s\n", commands[name].usage, commands[name].help)
+	}
+	tw.Flush()
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Formats : table (par défaut), json, csv, jsonl, md")
+	fmt.Fprintln(out, "Ordres  :", sortOrderNames())
+	fmt.Fprintln(out, "Colonnes:", columnNames())
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "La variable %s remplace l'URL de l'API (%s)\n", EnvAPIURL, api.DefaultBaseURL)
+	fmt.Fprintf(out, "%s=fichier enregistre les échanges HTTP, %s=fichier les rejoue sans réseau\n", EnvRecord, EnvReplay)
+	return nil
+}
+
+// newFlagSet crée le jeu d'options d'une sous-commande
+// Les erreurs sont renvoyées plutôt qu'affichées par le paquet flag
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("groupie "+name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parseFlags lit les options et renvoie les arguments positionnels
+// Contrairement à flag.Parse, les options peuvent suivre les arguments : show "Queen" --format json
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, usageError{fs.Name() + " : voir groupie help"}
+			}
+			return nil, usageError{fmt.Sprintf("%s : %v", fs.Name(), err)}
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// listOptions sont les options communes à list et search
+type listOptions struct {
+	format  *string
+	sort    *string
+	columns *string
+}
+
+func addListFlags(fs *flag.FlagSet, defaultSort api.SortOrder) listOptions {
+	return listOptions{
+		format:  fs.String("format", "table", "format de sortie : table, json, csv, jsonl, md"),
+		sort:    fs.String("sort", string(defaultSort), "ordre de tri"),
+		columns: fs.String("columns", "", "colonnes séparées par des virgules (toutes par défaut)"),
+	}
+}
+
+// parse valide les options communes
+func (o listOptions) parse() (api.ExportFormat, api.SortOrder, []api.Column, error) {
+	format, err := api.ParseExportFormat(*o.format)
+	if err != nil {
+		return "", "", nil, usageError{err.Error()}
+	}
+	order, ok := api.ParseSortOrder(*o.sort)
+	if !ok {
+		return "", "", nil, usageError{fmt.Sprintf("ordre de tri inconnu %q (%s)", *o.sort, sortOrderNames())}
+	}
+	columns, err := api.ParseColumns(*o.columns)
+	if err != nil {
+		return "", "", nil, usageError{err.Error()}
+	}
+	return format, order, columns, nil
+}
+
+func runList(args []string, out io.Writer) error {
+	fs := newFlagSet("list")
+	opts := addListFlags(fs, api.SortNameAsc)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	format, order, columns, err := opts.parse()
+	if err != nil {
+		return err
+	}
+
+	artists, concerts, err := api.FetchCatalog()
+	if err != nil {
+		return err
+	}
+	api.SortArtists(artists, order, concerts, nil)
+	return api.ExportArtists(out, artists, concerts, format, columns, nil)
+}
+
+func runSearch(args []string, out io.Writer) error {
+	fs := newFlagSet("search")
+	opts := addListFlags(fs, api.SortRelevance)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return usageError{"search : requête attendue, ex: groupie search member:freddie"}
+	}
+	format, order, columns, err := opts.parse()
+	if err != nil {
+		return err
+	}
+
+	text := strings.Join(positional, " ")
+	query := api.ParseQuery(text)
+	artists, concerts, err := api.FetchCatalog()
+	if err != nil {
+		return err
+	}
+	var results []api.Artist
+	for _, a := range artists {
+		if query.Match(a, concerts[a.ID]) {
+			results = append(results, a)
+		}
+	}
+
+	// La pertinence se calcule sur le texte libre de la requête
+	api.SortArtists(results, order, concerts, query.FreeTerms())
+	return api.ExportArtists(out, results, concerts, format, columns, nil)
+}
+
+func runServe(args []string, out io.Writer) error {
+	fs := newFlagSet("serve")
+	addr := fs.String("addr", ":8080", "adresse d'écoute")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageError{fmt.Sprintf("serve : argument inattendu %q", positional[0])}
+	}
+
+	artists, concerts, err := api.FetchCatalog()
+	if err != nil {
+		return err
+	}
+	// Les lieux sont géocodés en arrière-plan, au rythme accepté par le géocodeur :
+	// l'API REST ne renvoie que les coordonnées déjà trouvées
+	go geo.Prefetch(concertPlaces(concerts))
+	handler, err := web.New(artists, concerts, geo.Known)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	host := *addr
+	if strings.HasPrefix(host, ":") {
+		host = "localhost" + host
+	}
+	fmt.Fprintf(out, "Groupie Tracker disponible sur http://%s (Ctrl+C pour arrêter)\n", host)
+	return server.ListenAndServe()
+}
+
+func runFakeAPI(args []string, out io.Writer) error {
+	fs := newFlagSet("fake-api")
+	addr := fs.String("addr", ":8081", "adresse d'écoute")
+	var opts fakeapi.Options
+	fs.DurationVar(&opts.Latency, "latency", 0, "délai ajouté à chaque réponse, ex: 200ms")
+	fs.Float64Var(&opts.Fail, "fail", 0, "probabilité d'une erreur 500 (0 à 1)")
+	fs.Float64Var(&opts.Truncate, "truncate", 0, "probabilité d'un corps tronqué (0 à 1)")
+	fs.Float64Var(&opts.Malformed, "malformed", 0, "probabilité d'un JSON invalide (0 à 1)")
+	fs.Int64Var(&opts.Seed, "seed", 1, "graine du tirage des pannes")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageError{fmt.Sprintf("fake-api : argument inattendu %q", positional[0])}
+	}
+	if opts.Fail+opts.Truncate+opts.Malformed > 1 {
+		return usageError{"fake-api : la somme des probabilités de panne dépasse 1"}
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           fakeapi.New(opts),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	fmt.Fprintf(out, "Fausse API sur %s (Ctrl+C pour arrêter)\n", fakeapi.URL(*addr))
+	fmt.Fprintf(out, "Pour l'utiliser : %s=%s groupie\n", EnvAPIURL, fakeapi.URL(*addr))
+	return server.ListenAndServe()
+}
+
+// concertPlaces liste les lieux des concerts, chacun une fois, par ordre alphabétique
+func concertPlaces(concerts map[int][]api.Concert) []string {
+	seen := map[string]bool{}
+	var places []string
+	for _, list := range concerts {
+		for _, c := range list {
+			if place := c.Place(); !seen[place] {
+				seen[place] = true
+				places = append(places, place)
+			}
+		}
+	}
+	sort.Strings(places)
+	return places
+}
+
+// findArtist retrouve un artiste par identifiant ou par nom (sans tenir compte de la casse)
+// À défaut de nom exact, un nom contenant le texte est accepté s'il est le seul
+func findArtist(artists []api.Artist, key string) (api.Artist, error) {
+	if id, err := strconv.Atoi(key); err == nil {
+		for _, a := range artists {
+			if a.ID == id {
+				return a, nil
+			}
+		}
+		return api.Artist{}, fmt.Errorf("aucun artiste avec l'identifiant %d", id)
+	}
+
+	var partial []api.Artist
+	for _, a := range artists {
+		if strings.EqualFold(a.Name, key) {
+			return a, nil
+		}
+		if strings.Contains(strings.ToLower(a.Name), strings.ToLower(key)) {
+			partial = append(partial, a)
+		}
+	}
+	switch len(partial) {
+	case 0:
+		return api.Artist{}, fmt.Errorf("aucun artiste nommé %q", key)
+	case 1:
+		return partial[0], nil
+	}
+	names := make([]string, len(partial))
+	for i, a := range partial {
+		names[i] = a.Name
+	}
+	return api.Artist{}, fmt.Errorf("%q est ambigu : %s", key, strings.Join(names, ", "))
+}
+
+func runShow(args []string, out io.Writer) error {
+	fs := newFlagSet("show")
+	formatFlag := fs.String("format", "table", "format de sortie : table, json, csv")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return usageError{"show : nom ou identifiant d'artiste attendu"}
+	}
+	format, err := api.ParseExportFormat(*formatFlag)
+	if err != nil {
+		return usageError{err.Error()}
+	}
+
+	artists, concerts, err := api.FetchCatalog()
+	if err != nil {
+		return err
+	}
+	artist, err := findArtist(artists, strings.Join(positional, " "))
+	if err != nil {
+		return err
+	}
+	played := concerts[artist.ID]
+
+	switch format {
+	case api.FormatTable:
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "Nom\t%s\n", artist.Name)
+		fmt.Fprintf(tw, "Identifiant\t%d\n", artist.ID)
+		fmt.Fprintf(tw, "Membres\t%s\n", strings.Join(artist.Members, ", "))
+		fmt.Fprintf(tw, "Création\t%d\n", artist.CreationDate)
+		fmt.Fprintf(tw, "Premier album\t%s\n", artist.FirstAlbum)
+		fmt.Fprintf(tw, "Concerts\t%d\n", len(played))
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		if len(played) > 0 {
+			fmt.Fprintln(out)
+			return writeConcerts(out, played, nil, api.FormatTable)
+		}
+		return nil
+	case api.FormatJSON:
+		rows := concertRows(played, nil)
+		if rows == nil {
+			rows = []concertRow{}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			api.Artist
+			Concerts []concertRow `json:"concerts"`
+		}{artist, rows})
+	}
+	// Les autres formats listent les concerts de l'artiste
+	return writeConcerts(out, played, map[int]string{artist.ID: artist.Name}, format)
+}
+
+func runConcerts(args []string, out io.Writer) error {
+	fs := newFlagSet("concerts")
+	formatFlag := fs.String("format", "table", "format de sortie : table, json, csv, jsonl, md")
+	artistFlag := fs.String("artist", "", "nom ou identifiant de l'artiste")
+	city := fs.String("city", "", "ville (recherche partielle)")
+	country := fs.String("country", "", "pays (recherche partielle)")
+	year := fs.Int("year", 0, "année des concerts")
+	fromFlag := fs.String("from", "", "à partir du (AAAA ou JJ-MM-AAAA)")
+	toFlag := fs.String("to", "", "jusqu'au (AAAA ou JJ-MM-AAAA)")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usageError{fmt.Sprintf("concerts : argument inattendu %q", positional[0])}
+	}
+	format, err := api.ParseExportFormat(*formatFlag)
+	if err != nil {
+		return usageError{err.Error()}
+	}
+	from, err := api.ParseDateBound(*fromFlag, false)
+	if err != nil {
+		return usageError{fmt.Sprintf("date --from invalide %q (AAAA ou JJ-MM-AAAA)", *fromFlag)}
+	}
+	to, err := api.ParseDateBound(*toFlag, true)
+	if err != nil {
+		return usageError{fmt.Sprintf("date --to invalide %q (AAAA ou JJ-MM-AAAA)", *toFlag)}
+	}
+	if *year != 0 {
+		if *fromFlag != "" || *toFlag != "" {
+			return usageError{"concerts : --year ne se combine pas avec --from ou --to"}
+		}
+		from = time.Date(*year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		to = time.Date(*year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+
+	artists, concerts, err := api.FetchCatalog()
+	if err != nil {
+		return err
+	}
+	names := map[int]string{}
+	for _, a := range artists {
+		names[a.ID] = a.Name
+	}
+	filter := api.ConcertFilter{City: *city, Country: *country, From: from, To: to}
+	if *artistFlag != "" {
+		a, err := findArtist(artists, *artistFlag)
+		if err != nil {
+			return err
+		}
+		filter.ArtistID = a.ID
+	}
+
+	matches := api.FindConcerts(concerts, filter)
+	return writeConcerts(out, matches, names, format)
+}
+
+// concertRow est un concert tel qu'écrit par la ligne de commande
+type concertRow struct {
+	Date    string `json:"date"`
+	Artist  string `json:"artist,omitempty"`
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+// concertRows prépare les concerts pour l'écriture
+// names peut être nil : la colonne artiste est alors omise
+func concertRows(concerts []api.Concert, names map[int]string) []concertRow {
+	var rows []concertRow
+	for _, c := range concerts {
+		rows = append(rows, concertRow{
+			Date:    c.Date.Format(api.DateLayout),
+			Artist:  names[c.ArtistID],
+			City:    c.City,
+			Country: c.Country,
+		})
+	}
+	return rows
+}
+
+// writeConcerts écrit une liste de concerts dans le format demandé
+func writeConcerts(out io.Writer, concerts []api.Concert, names map[int]string, format api.ExportFormat) error {
+	rows := concertRows(concerts, names)
+	header := []string{"Date", "Ville", "Pays"}
+	keys := []string{"date", "city", "country"}
+	fields := func(r concertRow) []string { return []string{r.Date, r.City, r.Country} }
+	if names != nil {
+		header = []string{"Date", "Artiste", "Ville", "Pays"}
+		keys = []string{"date", "artist", "city", "country"}
+		fields = func(r concertRow) []string { return []string{r.Date, r.Artist, r.City, r.Country} }
+	}
+
+	switch format {
+	case api.FormatTable:
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+		for _, r := range rows {
+			fmt.Fprintln(tw, strings.Join(fields(r), "\t"))
+		}
+		return tw.Flush()
+	case api.FormatCSV:
+		cw := csv.NewWriter(out)
+		cw.Write(keys)
+		for _, r := range rows {
+			cw.Write(fields(r))
+		}
+		cw.Flush()
+		return cw.Error()
+	case api.FormatJSON:
+		if rows == nil {
+			rows = []concertRow{}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	case api.FormatJSONL:
+		enc := json.NewEncoder(out)
+		for _, r := range rows {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	case api.FormatMarkdown:
+		cell := strings.NewReplacer("|", `\|`)
+		fmt.Fprintf(out, "| %s |\n|%s\n", strings.Join(header, " | "), strings.Repeat(" --- |", len(header)))
+		for _, r := range rows {
+			cells := fields(r)
+			for i := range cells {
+				cells[i] = cell.Replace(cells[i])
+			}
+			fmt.Fprintf(out, "| %s |\n", strings.Join(cells, " | "))
+		}
+		return nil
+	}
+	return errors.New("format non pris en charge pour les concerts : " + string(format))
+}
+
+// sortOrderNames liste les ordres de tri acceptés par --sort
+func sortOrderNames() string {
+	names := make([]string, len(api.SortOrders))
+	for i, o := range api.SortOrders {
+		names[i] = string(o)
+	}
+	return strings.Join(names, ", ")
+}
+
+// columnNames liste les colonnes acceptées par --columns
+func columnNames() string {
+	names := make([]string, len(api.Columns))
+	for i, c := range api.Columns {
+		names[i] = string(c)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"groupie/fakeapi"
+	api "groupie/models"
+)
+
+// useFakeAPI branche l'API sur un faux serveur le temps du test
+func useFakeAPI(t *testing.T, opts fakeapi.Options) {
+	t.Helper()
+	ts := httptest.NewServer(fakeapi.New(opts))
+	previous := api.BaseURL()
+	api.SetBaseURL(ts.URL + "/api")
+	t.Cleanup(func() {
+		api.SetBaseURL(previous)
+		ts.Close()
+	})
+}
+
+func TestRunHelp(t *testing.T) {
+	for _, args := range [][]string{nil, {"help"}, {"--help"}} {
+		var out bytes.Buffer
+		if err := Run(args, &out); err != nil {
+			t.Fatalf("Run(%q) : %v", args, err)
+		}
+		if !strings.Contains(out.String(), "concerts [--artist a]") {
+			t.Errorf("Run(%q) n'affiche pas l'aide :\n%s", args, out.String())
+		}
+	}
+}
+
+func TestRunUsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"dance"},
+		{"list", "--sort", "color"},
+		{"list", "--columns", "id,shoe"},
+		{"search"},
+		{"concerts", "--year", "2019", "--from", "2018"},
+		{"concerts", "--year", "2019", "--to", "2020"},
+	} {
+		err := Run(args, &bytes.Buffer{})
+		if !errors.As(err, &usageError{}) {
+			t.Errorf("Run(%q) = %v, attendu une erreur de syntaxe", args, err)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	useFakeAPI(t, fakeapi.Options{})
+
+	var out bytes.Buffer
+	if err := Run([]string{"list", "--format", "csv", "--columns", "name", "--sort", "name-desc"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	want := "name\nXXXTentacion\nSOJA\nScorpions\nQueen\nPink Floyd\n"
+	if out.String() != want {
+		t.Errorf("list =\n%s\nattendu\n%s", out.String(), want)
+	}
+}
+
+func TestListAPIError(t *testing.T) {
+	useFakeAPI(t, fakeapi.Options{Fail: 1})
+
+	err := Run([]string{"list"}, &bytes.Buffer{})
+	if err == nil || errors.As(err, &usageError{}) {
+		t.Fatalf("list = %v, attendu l'erreur de l'API", err)
+	}
+	if _, ok := api.AsStatusError(err); !ok {
+		t.Errorf("list = %v, attendu une erreur HTTP", err)
+	}
+}
+
+func TestFindArtist(t *testing.T) {
+	artists := []api.Artist{
+		{ID: 1, Name: "Queen"},
+		{ID: 3, Name: "Pink Floyd"},
+		{ID: 4, Name: "Scorpions"},
+		{ID: 6, Name: "Queens of the Stone Age"},
+	}
+	for _, tc := range []struct {
+		key    string
+		wantID int // 0 : erreur attendue
+	}{
+		{"3", 3},
+		{"queen", 1}, // Nom exact préféré à « Queens of the Stone Age »
+		{"FLOYD", 3}, // Seul nom contenant le texte
+		{"stone age", 6},
+		{"o", 0}, // Ambigu
+		{"abba", 0},
+		{"42", 0},
+	} {
+		a, err := findArtist(artists, tc.key)
+		if tc.wantID == 0 {
+			if err == nil {
+				t.Errorf("findArtist(%q) = %s, attendu une erreur", tc.key, a.Name)
+			}
+			continue
+		}
+		if err != nil || a.ID != tc.wantID {
+			t.Errorf("findArtist(%q) = %d, %v, attendu %d", tc.key, a.ID, err, tc.wantID)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"groupie/cassette"
+	"groupie/geo"
+	api "groupie/models"
+)
+
+// Variables d'environnement lues par la ligne de commande et l'interface graphique
+const (
+	EnvAPIURL = "GROUPIE_API_URL" // Remplace l'URL de l'API, ex: une fausse API locale
+	EnvRecord = "GROUPIE_RECORD"  // Enregistre les échanges HTTP dans ce fichier
+	EnvReplay = "GROUPIE_REPLAY"  // Rejoue les échanges HTTP de ce fichier, sans réseau
+)
+
+// Main exécute une sous-commande comme un programme : l'URL de l'API et la cassette sont lues
+// dans l'environnement, les erreurs écrites dans stderr. Renvoie le code de sortie
+// (2 pour une erreur de syntaxe)
+func Main(args []string, stdout, stderr io.Writer) int {
+	if url := os.Getenv(EnvAPIURL); url != "" {
+		api.SetBaseURL(url)
+	}
+	saveCassette, err := StartCassette()
+	if err != nil {
+		fmt.Fprintln(stderr, "groupie: cassette illisible:", err)
+		return 1
+	}
+
+	err = Run(args, stdout)
+	saveCassette()
+	if err != nil {
+		fmt.Fprintln(stderr, "groupie:", err)
+		if errors.As(err, &usageError{}) {
+			return 2
+		}
+		return 1
+	}
+	return 0
+}
+
+// StartCassette branche la cassette de l'environnement sur les clients HTTP de l'API,
+// du géocodeur et sur ceux passés en argument (tuiles, photos...)
+// La fonction renvoyée écrit la cassette enregistrée : à appeler avant de quitter
+// Un arrêt par Ctrl+C ou SIGTERM (ex: groupie serve) l'écrit aussi avant de terminer le programme
+func StartCassette(clients ...*api.Client) (func(), error) {
+	path, mode := os.Getenv(EnvReplay), cassette.Replay
+	if path == "" {
+		path, mode = os.Getenv(EnvRecord), cassette.Record
+	}
+	if path == "" {
+		return func() {}, nil
+	}
+
+	rec, err := cassette.New(path, mode, nil)
+	if err != nil {
+		return nil, err
+	}
+	api.SetTransport(rec)
+	geo.SetTransport(rec)
+	for _, c := range clients {
+		c.SetTransport(rec)
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	var once sync.Once
+	save := func() {
+		once.Do(func() {
+			signal.Stop(stop)
+			close(stop)
+			if err := rec.Save(); err != nil {
+				log.Println("Cassette non enregistrée:", err)
+			}
+		})
+	}
+	go func() {
+		sig, ok := <-stop
+		if !ok {
+			return
+		}
+		save()
+		// Code de sortie habituel d'un programme arrêté par un signal : 130 pour Ctrl+C
+		code := 1
+		if s, ok := sig.(syscall.Signal); ok {
+			code = 128 + int(s)
+		}
+		os.Exit(code)
+	}()
+	return save, nil
+}
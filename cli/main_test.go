@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"groupie/fakeapi"
+	api "groupie/models"
+)
+
+// Le test se relance dans un sous-processus, arrêté par un signal
+const envSignalChild = "GROUPIE_TEST_SIGNAL_CHILD"
+
+func TestCassetteSavedOnSignal(t *testing.T) {
+	if os.Getenv(envSignalChild) != "" {
+		signalChild(t)
+		return
+	}
+	if runtime.GOOS == "windows" {
+		t.Skip("pas de SIGINT envoyé à soi-même sous Windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCassetteSavedOnSignal$")
+	cmd.Env = append(os.Environ(), envSignalChild+"=1", EnvRecord+"="+path)
+	err := cmd.Run()
+
+	var exit *exec.ExitError
+	if !errors.As(err, &exit) || exit.ExitCode() != 130 {
+		t.Fatalf("sous-processus : %v, attendu le code de sortie 130", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("cassette non écrite : %v", err)
+	}
+	if !strings.Contains(string(data), "/api/artists") {
+		t.Errorf("cassette sans la requête des artistes :\n%s", data)
+	}
+}
+
+// signalChild enregistre une requête puis s'envoie Ctrl+C, comme un groupie serve interrompu
+func signalChild(t *testing.T) {
+	useFakeAPI(t, fakeapi.Options{})
+	if _, err := StartCassette(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.FetchArtists(); err != nil {
+		t.Fatal(err)
+	}
+	self, _ := os.FindProcess(os.Getpid())
+	self.Signal(os.Interrupt)
+	time.Sleep(5 * time.Second)
+	t.Fatal("le signal n'a pas arrêté le programme")
+}
@@ -0,0 +1,13 @@
+// Commande groupie-cli : la ligne de commande de Groupie Tracker, sans interface graphique
+// Elle se construit sans Fyne ni bibliothèque graphique, ex: sur un serveur
+package main
+
+import (
+	"os"
+
+	"groupie/cli"
+)
+
+func main() {
+	os.Exit(cli.Main(os.Args[1:], os.Stdout, os.Stderr))
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+
+	api "groupie/models"
+)
+
+// Nombre d'artistes comparables côte à côte
+const (
+	minCompared = 2
+	maxCompared = 4
+)
+
+// newCompareView crée la comparaison côte à côte de 2 à 4 artistes
+// Les lieux où plusieurs artistes ont joué sont mis en évidence
+func newCompareView(artists []api.Artist, concerts map[int][]api.Concert, onBack func()) fyne.CanvasObject {
+	cmp := api.Compare(artists, concerts)
+
+	names := map[int]string{}
+	for _, a := range artists {
+		names[a.ID] = a.Name
+	}
+
+	// Une colonne par artiste
+	columns := container.NewGridWithColumns(len(cmp.Artists))
+	for _, s := range cmp.Artists {
+		img := newArtistImage(galleryThumbSize)
+		img.Load(s.Image, galleryThumbSize)
+
+		header := widget.NewLabelWithStyle(s.Name, fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+		header.Wrapping = fyne.TextWrapWord
+
+		info := widget.NewLabel(fmt.Sprintf(
+			lang.L("Membres : %s\nCréation : %d\nPremier album : %s\nConcerts : %d\nPays : %s"),
+			strings.Join(s.Members, ", "),
+			s.CreationDate,
+			formatAPIDate(s.FirstAlbum),
+			s.ConcertCount,
+			strings.Join(s.Countries, ", "),
+		))
+		info.Wrapping = fyne.TextWrapWord
+
+		// Lieux visités, les lieux communs en couleur et en gras
+		cities := widget.NewRichText()
+		cities.Wrapping = fyne.TextWrapWord
+		for _, city := range s.Cities {
+			style := widget.RichTextStyleParagraph
+			if cmp.IsShared(city) {
+				style.ColorName = colorNameAccent
+				style.TextStyle = fyne.TextStyle{Bold: true}
+			}
+			cities.Segments = append(cities.Segments, &widget.TextSegment{Text: city, Style: style})
+		}
+
+		columns.Add(createCard(container.NewVBox(
+			header,
+			container.NewCenter(img),
+			widget.NewSeparator(),
+			info,
+			widget.NewSeparator(),
+			widget.NewLabelWithStyle(lang.L("Lieux de concerts :"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			cities,
+		)))
+	}
+
+	// Lieux communs
+	shared := lang.L("Aucun lieu en commun")
+	if len(cmp.SharedCities) > 0 {
+		lines := make([]string, len(cmp.SharedCities))
+		for i, c := range cmp.SharedCities {
+			artistNames := make([]string, len(c.ArtistIDs))
+			for j, id := range c.ArtistIDs {
+				artistNames[j] = names[id]
+			}
+			lines[i] = fmt.Sprintf("%s : %s", c.Place, strings.Join(artistNames, ", "))
+		}
+		shared = strings.Join(lines, "\n")
+	}
+	sharedLabel := widget.NewLabel(shared)
+	sharedLabel.Wrapping = fyne.TextWrapWord
+
+	// Périodes où les deux artistes ont joué à quelques jours d'écart
+	overlaps := lang.L("Aucune période de tournée commune")
+	if len(cmp.Overlaps) > 0 {
+		lines := make([]string, len(cmp.Overlaps))
+		for i, o := range cmp.Overlaps {
+			lines[i] = fmt.Sprintf(lang.L("%s et %s : du %s au %s"),
+				names[o.ArtistA], names[o.ArtistB],
+				formatDate(o.From), formatDate(o.To))
+			if len(o.Cities) > 0 {
+				lines[i] += ", " + fmt.Sprintf(lang.L("dans les mêmes villes : %s"), strings.Join(o.Cities, ", "))
+			}
+		}
+		overlaps = strings.Join(lines, "\n")
+	}
+	overlapsLabel := widget.NewLabel(overlaps)
+	overlapsLabel.Wrapping = fyne.TextWrapWord
+
+	backBtn := widget.NewButton(lang.L("Retour (Échap)"), onBack)
+	title := widget.NewLabelWithStyle(lang.L("Comparaison"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+
+	content := container.NewVBox(
+		columns,
+		createCard(container.NewVBox(
+			widget.NewLabelWithStyle(lang.L("Lieux en commun :"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			sharedLabel,
+		)),
+		createCard(container.NewVBox(
+			widget.NewLabelWithStyle(lang.L("Tournées simultanées :"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			overlapsLabel,
+		)),
+	)
+
+	return container.NewBorder(
+		container.NewBorder(nil, nil, backBtn, nil, title),
+		nil, nil, nil,
+		container.NewPadded(container.NewVScroll(content)),
+	)
+}
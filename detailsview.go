@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"groupie/imagecache"
+	api "groupie/models"
+)
+
+// DetailsView est la page détails d'un artiste : photo, informations, lieux, dates et tournée
+type DetailsView struct {
+	// Événements, à renseigner par l'application
+	OnMap      func(place string) // Carte demandée, centrée sur place s'il est renseigné
+	OnFavorite func()             // Étoile cliquée
+	OnExport   func()             // Export .ics demandé
+	OnBack     func()
+
+	artist  api.Artist
+	favBtn  *widget.Button
+	content fyne.CanvasObject
+}
+
+// newDetailsView crée la page détails d'un artiste
+// concerts est nil si les relations de l'artiste ne sont pas dans l'index : elles sont alors chargées
+func newDetailsView(artist api.Artist, concerts []api.Concert, favorite bool) *DetailsView {
+	v := &DetailsView{artist: artist}
+
+	// Header avec titre stylisé
+	header := widget.NewRichTextFromMarkdown("# " + artist.Name)
+	header.Wrapping = fyne.TextWrapWord
+
+	// Image de l'artiste avec style (téléchargée et décodée en arrière-plan)
+	var photo *artistImage
+	if artist.Image != "" {
+		photo = newArtistImage(350)
+		photo.Load(artist.Image, imagecache.Original)
+	}
+
+	// Informations principales
+	firstAlbumLabel := createInfoLabel("", fmt.Sprintf(lang.L("Premier album : %s"), formatAPIDate(artist.FirstAlbum)))
+	membersLabel := createInfoLabel("", fmt.Sprintf(lang.L("Membres : %s"), strings.Join(artist.Members, ", ")))
+	creationLabel := createInfoLabel("", fmt.Sprintf(lang.L("Année de création : %d"), artist.CreationDate))
+
+	// Card pour les infos principales
+	infoCard := createCard(container.NewVBox(
+		firstAlbumLabel,
+		widget.NewSeparator(),
+		membersLabel,
+		widget.NewSeparator(),
+		creationLabel,
+	))
+
+	// Lieux et dates chargés en arrière-plan
+	locTask := newTask(func() ([]string, error) { return api.FetchLocationList(artist.LocationsURL) })
+	dateTask := newTask(func() ([]string, error) { return api.FetchDateList(artist.ConcertDates) })
+	locView := newTaskView(locTask, lang.L("Chargement des localisations..."), func(locations []string) fyne.CanvasObject {
+		return wrappedLabel(lang.L("Localisations :") + "\n" + strings.Join(locations, "\n"))
+	})
+	dateView := newTaskView(dateTask, lang.L("Chargement des dates..."), func(dates []string) fyne.CanvasObject {
+		formatted := make([]string, len(dates))
+		for i, d := range dates {
+			formatted[i] = formatAPIDate(d)
+		}
+		return wrappedLabel(lang.L("Dates :") + "\n" + strings.Join(formatted, "\n"))
+	})
+
+	// Chronologie de la tournée : un clic centre la carte sur le lieu du concert
+	showOnMap := func(c api.Concert) { v.showMap(c.Place()) }
+	var timeline fyne.CanvasObject
+	if concerts != nil {
+		timeline = newTimeline(concerts, showOnMap)
+	} else {
+		// Relations absentes de l'index : on les charge pour cet artiste
+		relTask := newTask(func() (api.RelationData, error) { return api.FetchRelation(artist.RelationsURL) })
+		timeline = newTaskView(relTask, lang.L("Chargement des concerts..."), func(rel api.RelationData) fyne.CanvasObject {
+			return newTimeline(api.ConcertsFromRelation(rel), showOnMap)
+		})
+	}
+
+	// Cards pour les sections de données
+	locCard := createCard(locView)
+	dateCard := createCard(dateView)
+	relCard := createCard(container.NewVBox(
+		widget.NewLabelWithStyle(lang.L("Tournée :"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		timeline,
+	))
+
+	// Boutons avec style amélioré
+	mapBtn := widget.NewButton(lang.L("Voir sur la carte"), func() { v.showMap("") })
+	mapBtn.Importance = widget.HighImportance
+
+	v.favBtn = widget.NewButtonWithIcon(lang.L("Favori"), starBorderIcon, func() {
+		if v.OnFavorite != nil {
+			v.OnFavorite()
+		}
+	})
+	v.SetFavorite(favorite)
+
+	backBtn := widget.NewButton(lang.L("Retour (Échap)"), func() {
+		if v.OnBack != nil {
+			v.OnBack()
+		}
+	})
+
+	icsBtn := widget.NewButtonWithIcon(lang.L("Exporter (.ics)"), theme.DocumentSaveIcon(), func() {
+		if v.OnExport != nil {
+			v.OnExport()
+		}
+	})
+
+	buttonBar := container.NewGridWithColumns(4, mapBtn, v.favBtn, icsBtn, backBtn)
+
+	// Organisation du contenu
+	content := container.NewVBox(container.NewPadded(header))
+	if photo != nil {
+		content.Add(createCard(container.NewCenter(photo)))
+		content.Add(container.NewPadded(widget.NewSeparator()))
+	}
+	content.Add(infoCard)
+	content.Add(container.NewPadded(widget.NewSeparator()))
+	content.Add(locCard)
+	content.Add(dateCard)
+	content.Add(relCard)
+	content.Add(container.NewPadded(widget.NewSeparator()))
+	content.Add(buttonBar)
+
+	v.content = container.NewPadded(container.NewVScroll(content))
+	return v
+}
+
+// showMap déclenche l'événement OnMap
+func (v *DetailsView) showMap(place string) {
+	if v.OnMap != nil {
+		v.OnMap(place)
+	}
+}
+
+// SetFavorite met à jour l'étoile du bouton favori
+func (v *DetailsView) SetFavorite(favorite bool) {
+	if favorite {
+		v.favBtn.SetIcon(starIcon)
+	} else {
+		v.favBtn.SetIcon(starBorderIcon)
+	}
+}
+
+// wrappedLabel crée un label dont le texte passe à la ligne
+func wrappedLabel(text string) *widget.Label {
+	label := widget.NewLabel(text)
+	label.Wrapping = fyne.TextWrapWord
+	return label
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	api "groupie/models"
+)
+
+// Libellés des formats d'export
+var exportFormatLabels = map[api.ExportFormat]string{
+	api.FormatCSV:      "CSV",
+	api.FormatJSONL:    "JSON Lines",
+	api.FormatMarkdown: "Markdown",
+}
+
+// showExportDialog propose d'exporter les artistes affichés :
+// choix du format et des colonnes, puis du fichier de destination
+func showExportDialog(w fyne.Window, artists []api.Artist, concerts map[int][]api.Concert) {
+	if len(artists) == 0 {
+		dialog.ShowInformation(lang.L("Exporter"), lang.L("Aucun artiste à exporter"), w)
+		return
+	}
+
+	formats := make([]string, len(api.ExportFormats))
+	for i, f := range api.ExportFormats {
+		formats[i] = exportFormatLabels[f]
+	}
+	formatRadio := widget.NewRadioGroup(formats, nil)
+	formatRadio.Horizontal = true
+	formatRadio.Required = true
+	formatRadio.SetSelected(formats[0])
+
+	columns := make([]string, len(api.Columns))
+	for i, c := range api.Columns {
+		columns[i] = columnLabel(c)
+	}
+	columnChecks := widget.NewCheckGroup(columns, nil)
+	columnChecks.SetSelected(columns)
+
+	form := container.NewVBox(
+		widget.NewLabelWithStyle(lang.L("Format :"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		formatRadio,
+		widget.NewLabelWithStyle(lang.L("Colonnes :"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		columnChecks,
+	)
+
+	title := plural("Exporter %d artiste(s)", len(artists))
+	dialog.ShowCustomConfirm(title, lang.L("Enregistrer..."), lang.L("Annuler"), form, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		format := api.FormatCSV
+		for f, label := range exportFormatLabels {
+			if label == formatRadio.Selected {
+				format = f
+			}
+		}
+		// Colonnes dans l'ordre de référence, quel que soit l'ordre des clics
+		var selected []api.Column
+		for _, c := range api.Columns {
+			for _, label := range columnChecks.Selected {
+				if label == columnLabel(c) {
+					selected = append(selected, c)
+				}
+			}
+		}
+		if len(selected) == 0 {
+			dialog.ShowInformation(lang.L("Exporter"), lang.L("Choisissez au moins une colonne"), w)
+			return
+		}
+
+		save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				return
+			}
+			defer writer.Close()
+			if err := api.ExportArtists(writer, artists, concerts, format, selected, columnLabel); err != nil {
+				dialog.ShowError(err, w)
+			}
+		}, w)
+		save.SetFileName(lang.L("artistes") + format.Extension())
+		save.SetFilter(storage.NewExtensionFileFilter([]string{format.Extension()}))
+		save.Show()
+	}, w)
+}
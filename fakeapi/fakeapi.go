@@ -0,0 +1,249 @@
+// Package fakeapi imite l'API Groupie Trackers à partir de données embarquées
+// Il sert aux tests et aux démonstrations sans réseau, avec des pannes simulées à la demande
+package fakeapi
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Données des artistes, au format de l'API d'origine
+// Les URL commencent par {{base}}, remplacé par l'adresse du serveur
+//
+//go:embed fixtures
+var fixtures embed.FS
+
+// Marqueur des URL dans les fichiers de données
+const basePlaceholder = "{{base}}"
+
+// Options règle les pannes simulées
+// Les probabilités vont de 0 (jamais) à 1 (à chaque requête)
+type Options struct {
+	Latency   time.Duration // Délai ajouté avant chaque réponse
+	Fail      float64       // Probabilité d'une erreur 500
+	Truncate  float64       // Probabilité d'un corps coupé en deux
+	Malformed float64       // Probabilité d'un JSON invalide
+	Seed      int64         // Graine du tirage des pannes, pour des essais reproductibles
+}
+
+// Server est un faux serveur de l'API, à utiliser comme http.Handler
+type Server struct {
+	mu       sync.Mutex
+	opts     Options
+	rand     *rand.Rand
+	requests int
+
+	mux       *http.ServeMux
+	artists   resource
+	locations resource
+	dates     resource
+	relation  resource
+}
+
+// resource est une liste de l'API, indexée par identifiant d'artiste
+type resource struct {
+	all  []byte                  // Réponse de la liste complète
+	byID map[int]json.RawMessage // Élément de chaque artiste
+}
+
+// New crée un faux serveur avec les pannes demandées
+func New(opts Options) *Server {
+	s := &Server{mux: http.NewServeMux()}
+	s.SetOptions(opts)
+
+	s.artists = loadResource("artists.json", false)
+	s.locations = loadResource("locations.json", true)
+	s.dates = loadResource("dates.json", true)
+	s.relation = loadResource("relation.json", true)
+
+	s.mux.HandleFunc("GET /api", s.handleIndex)
+	s.mux.HandleFunc("GET /api/images/{name}", s.handleImage)
+	for name, res := range map[string]*resource{
+		"artists":   &s.artists,
+		"locations": &s.locations,
+		"dates":     &s.dates,
+		"relation":  &s.relation,
+	} {
+		s.mux.HandleFunc("GET /api/"+name, func(w http.ResponseWriter, r *http.Request) {
+			s.writeJSON(w, r, http.StatusOK, res.all)
+		})
+		s.mux.HandleFunc("GET /api/"+name+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+			id, err := strconv.Atoi(r.PathValue("id"))
+			item, ok := res.byID[id]
+			if err != nil || !ok {
+				s.writeJSON(w, r, http.StatusNotFound, []byte(`{"error":"not found"}`))
+				return
+			}
+			s.writeJSON(w, r, http.StatusOK, item)
+		})
+	}
+	return s
+}
+
+// loadResource lit un fichier de données embarqué
+// Les listes indexées ont la forme {"index": [...]}, les autres sont un tableau
+func loadResource(name string, indexed bool) resource {
+	data, err := fixtures.ReadFile("fixtures/" + name)
+	if err != nil {
+		panic(err) // Fichier embarqué : ne peut manquer que si le paquet est mal construit
+	}
+	var items []json.RawMessage
+	if indexed {
+		var index struct {
+			Index []json.RawMessage `json:"index"`
+		}
+		err = json.Unmarshal(data, &index)
+		items = index.Index
+	} else {
+		err = json.Unmarshal(data, &items)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("fakeapi: %s invalide : %v", name, err))
+	}
+
+	res := resource{all: data, byID: make(map[int]json.RawMessage, len(items))}
+	for _, item := range items {
+		var key struct {
+			ID int `json:"id"`
+		}
+		if err := json.Unmarshal(item, &key); err != nil {
+			panic(fmt.Sprintf("fakeapi: %s invalide : %v", name, err))
+		}
+		res.byID[key.ID] = item
+	}
+	return res
+}
+
+// SetOptions change les pannes simulées ; le tirage repart de la graine
+func (s *Server) SetOptions(opts Options) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.opts = opts
+	s.rand = rand.New(rand.NewSource(opts.Seed))
+}
+
+// Requests renvoie le nombre de requêtes reçues
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	latency := s.opts.Latency
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// Pannes possibles d'une réponse JSON
+type fault int
+
+const (
+	faultNone fault = iota
+	faultFail
+	faultTruncate
+	faultMalformed
+)
+
+// drawFault tire la panne de la prochaine réponse
+func (s *Server) drawFault() fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := s.rand.Float64()
+	switch {
+	case u < s.opts.Fail:
+		return faultFail
+	case u < s.opts.Fail+s.opts.Truncate:
+		return faultTruncate
+	case u < s.opts.Fail+s.opts.Truncate+s.opts.Malformed:
+		return faultMalformed
+	}
+	return faultNone
+}
+
+// writeJSON envoie une réponse JSON, en appliquant la panne tirée
+func (s *Server) writeJSON(w http.ResponseWriter, r *http.Request, status int, body []byte) {
+	body = bytes.ReplaceAll(body, []byte(basePlaceholder), []byte(baseURL(r)))
+	w.Header().Set("Content-Type", "application/json")
+
+	switch s.drawFault() {
+	case faultFail:
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	case faultTruncate:
+		// La longueur annoncée est celle du corps complet : le client voit une fin inattendue
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(status)
+		w.Write(body[:len(body)/2])
+		return
+	case faultMalformed:
+		body = bytes.Replace(body, []byte(":"), []byte("="), 1)
+	}
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	base := baseURL(r)
+	body, _ := json.Marshal(map[string]string{
+		"artists":   base + "/artists",
+		"locations": base + "/locations",
+		"dates":     base + "/dates",
+		"relation":  base + "/relation",
+	})
+	s.writeJSON(w, r, http.StatusOK, body)
+}
+
+// handleImage génère une image unie, dont la couleur dépend du nom
+func (s *Server) handleImage(w http.ResponseWriter, r *http.Request) {
+	h := fnv.New32a()
+	h.Write([]byte(r.PathValue("name")))
+	sum := h.Sum32()
+
+	img := image.NewRGBA(image.Rect(0, 0, 200, 200))
+	fill := color.RGBA{R: uint8(sum), G: uint8(sum >> 8), B: uint8(sum >> 16), A: 255}
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: fill}, image.Point{}, draw.Src)
+
+	w.Header().Set("Content-Type", "image/jpeg")
+	jpeg.Encode(w, img, nil)
+}
+
+// baseURL renvoie l'adresse de l'API vue par le client, ex: "http://localhost:8081/api"
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + "/api"
+}
+
+// URL renvoie l'adresse de l'API d'un serveur lancé à addr, ex: ":8081"
+func URL(addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		addr = "localhost" + addr
+	}
+	return "http://" + addr + "/api"
+}
@@ -0,0 +1,180 @@
+package fakeapi_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"groupie/fakeapi"
+)
+
+// get envoie une requête au faux serveur et renvoie la réponse et son corps
+func get(t *testing.T, url string) (*http.Response, []byte, error) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	return resp, body, err
+}
+
+func newServer(t *testing.T, opts fakeapi.Options) (*fakeapi.Server, *httptest.Server) {
+	t.Helper()
+	fake := fakeapi.New(opts)
+	ts := httptest.NewServer(fake)
+	t.Cleanup(ts.Close)
+	return fake, ts
+}
+
+func TestArtistsUseServerURL(t *testing.T) {
+	_, ts := newServer(t, fakeapi.Options{})
+
+	resp, body, err := get(t, ts.URL+"/api/artists")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, attendu 200", resp.StatusCode)
+	}
+	var artists []struct {
+		ID        int    `json:"id"`
+		Name      string `json:"name"`
+		Relations string `json:"relations"`
+	}
+	if err := json.Unmarshal(body, &artists); err != nil {
+		t.Fatal(err)
+	}
+	if len(artists) == 0 {
+		t.Fatal("aucun artiste dans les données")
+	}
+	want := ts.URL + "/api/relation/1"
+	if artists[0].Relations != want {
+		t.Errorf("relations = %q, attendu %q", artists[0].Relations, want)
+	}
+}
+
+func TestByID(t *testing.T) {
+	_, ts := newServer(t, fakeapi.Options{})
+
+	for _, path := range []string{"/api/artists/1", "/api/locations/1", "/api/dates/1", "/api/relation/1"} {
+		resp, body, err := get(t, ts.URL+path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var item struct {
+			ID int `json:"id"`
+		}
+		if resp.StatusCode != http.StatusOK || json.Unmarshal(body, &item) != nil || item.ID != 1 {
+			t.Errorf("%s : status %d, corps %s", path, resp.StatusCode, body)
+		}
+	}
+
+	for _, path := range []string{"/api/artists/999", "/api/relation/abc"} {
+		resp, _, _ := get(t, ts.URL+path)
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("%s : status %d, attendu 404", path, resp.StatusCode)
+		}
+	}
+}
+
+func TestIndexedResources(t *testing.T) {
+	_, ts := newServer(t, fakeapi.Options{})
+
+	for _, path := range []string{"/api/locations", "/api/dates", "/api/relation"} {
+		_, body, err := get(t, ts.URL+path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var index struct {
+			Index []json.RawMessage `json:"index"`
+		}
+		if err := json.Unmarshal(body, &index); err != nil || len(index.Index) == 0 {
+			t.Errorf("%s : index vide ou invalide (%v)", path, err)
+		}
+	}
+}
+
+func TestImage(t *testing.T) {
+	_, ts := newServer(t, fakeapi.Options{})
+
+	resp, body, err := get(t, ts.URL+"/api/images/queen.jpeg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "image/jpeg" || len(body) == 0 {
+		t.Errorf("Content-Type = %q, %d octets", ct, len(body))
+	}
+}
+
+func TestFaults(t *testing.T) {
+	fake, ts := newServer(t, fakeapi.Options{Fail: 1})
+	resp, _, _ := get(t, ts.URL+"/api/artists")
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Fail = 1 : status %d, attendu 500", resp.StatusCode)
+	}
+
+	fake.SetOptions(fakeapi.Options{Truncate: 1})
+	if _, _, err := get(t, ts.URL+"/api/artists"); err == nil {
+		t.Error("Truncate = 1 : la lecture du corps aurait dû échouer")
+	}
+
+	fake.SetOptions(fakeapi.Options{Malformed: 1})
+	resp, body, err := get(t, ts.URL+"/api/artists")
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Malformed = 1 : status %d, erreur %v", resp.StatusCode, err)
+	}
+	if json.Valid(body) {
+		t.Error("Malformed = 1 : le corps est un JSON valide")
+	}
+
+	if n := fake.Requests(); n != 3 {
+		t.Errorf("Requests() = %d, attendu 3", n)
+	}
+}
+
+func TestFaultsAreReproducible(t *testing.T) {
+	opts := fakeapi.Options{Fail: 0.5, Seed: 42}
+	statuses := func() string {
+		_, ts := newServer(t, opts)
+		var b strings.Builder
+		for i := 0; i < 20; i++ {
+			resp, _, _ := get(t, ts.URL+"/api/dates/1")
+			if resp.StatusCode == http.StatusOK {
+				b.WriteByte('.')
+			} else {
+				b.WriteByte('x')
+			}
+		}
+		return b.String()
+	}
+
+	first, second := statuses(), statuses()
+	if first != second {
+		t.Errorf("tirages différents avec la même graine : %s / %s", first, second)
+	}
+	if !strings.Contains(first, ".") || !strings.Contains(first, "x") {
+		t.Errorf("Fail = 0.5 : tirage %s sans mélange de succès et d'erreurs", first)
+	}
+}
+
+func TestLatency(t *testing.T) {
+	_, ts := newServer(t, fakeapi.Options{Latency: 50 * time.Millisecond})
+
+	start := time.Now()
+	get(t, ts.URL+"/api/artists")
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("réponse en %v, attendu au moins 50ms", elapsed)
+	}
+}
+
+func TestURL(t *testing.T) {
+	if got := fakeapi.URL(":8081"); got != "http://localhost:8081/api" {
+		t.Errorf("URL(\":8081\") = %q", got)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// Clé des préférences contenant les IDs des artistes favoris
+const prefFavorites = "favorites"
+
+// Icônes étoile (Material Design), colorées selon le thème
+var (
+	starIcon = theme.NewPrimaryThemedResource(fyne.NewStaticResource("star.svg", []byte(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><path d="M12 17.27L18.18 21l-1.64-7.03L22 9.24l-7.19-.61L12 2 9.19 8.63 2 9.24l5.46 4.73L5.82 21z"/></svg>`)))
+	starBorderIcon = theme.NewThemedResource(fyne.NewStaticResource("star_border.svg", []byte(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><path d="M22 9.24l-7.19-.62L12 2 9.19 8.63 2 9.24l5.46 4.73L5.82 21 12 17.27 18.18 21l-1.63-7.03L22 9.24zM12 15.4l-3.76 2.27 1-4.28-3.32-2.88 4.38-.38L12 6.1l1.71 4.04 4.38.38-3.32 2.88 1 4.28L12 15.4z"/></svg>`)))
+)
+
+// favorites garde les artistes favoris de l'utilisateur, identifiés par Artist.ID
+// Chaque modification est sauvegardée immédiatement dans les préférences
+type favorites struct {
+	prefs fyne.Preferences
+	ids   map[int]bool
+}
+
+// favoritesFile est le format JSON d'export/import des favoris
+type favoritesFile struct {
+	Favorites []int `json:"favorites"`
+}
+
+// loadFavorites relit les favoris sauvegardés
+func loadFavorites(prefs fyne.Preferences) *favorites {
+	f := &favorites{prefs: prefs, ids: map[int]bool{}}
+	for _, id := range prefs.IntList(prefFavorites) {
+		f.ids[id] = true
+	}
+	return f
+}
+
+// Has indique si l'artiste est dans les favoris
+func (f *favorites) Has(id int) bool {
+	return f.ids[id]
+}
+
+// Toggle ajoute ou retire un artiste des favoris et renvoie son nouvel état
+func (f *favorites) Toggle(id int) bool {
+	if f.ids[id] {
+		delete(f.ids, id)
+	} else {
+		f.ids[id] = true
+	}
+	f.save()
+	return f.ids[id]
+}
+
+// IDs renvoie les IDs des favoris triés
+func (f *favorites) IDs() []int {
+	ids := make([]int, 0, len(f.ids))
+	for id := range f.ids {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// Icon renvoie l'icône étoile correspondant à l'état de l'artiste
+func (f *favorites) Icon(id int) fyne.Resource {
+	if f.ids[id] {
+		return starIcon
+	}
+	return starBorderIcon
+}
+
+// Export écrit les favoris au format JSON : {"favorites": [1, 5, 7]}
+func (f *favorites) Export(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(favoritesFile{Favorites: f.IDs()})
+}
+
+// Import ajoute aux favoris les artistes d'un fichier JSON exporté
+// Renvoie le nombre de favoris ajoutés
+func (f *favorites) Import(r io.Reader) (int, error) {
+	var file favoritesFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return 0, err
+	}
+	added := 0
+	for _, id := range file.Favorites {
+		if !f.ids[id] {
+			f.ids[id] = true
+			added++
+		}
+	}
+	f.save()
+	return added, nil
+}
+
+// save sauvegarde les favoris dans les préférences
+func (f *favorites) save() {
+	f.prefs.SetIntList(prefFavorites, f.IDs())
+}
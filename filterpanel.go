@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	api "groupie/models"
+)
+
+// FilterPanel est le menu des filtres : champs de recherche, période de concerts et favoris
+// Ses widgets sont liés aux filtres du Store ; chaque case affiche le nombre d'artistes
+// que trouverait son champ
+type FilterPanel struct {
+	// Événements, à renseigner par l'application
+	OnExportFavorites func()
+	OnImportFavorites func()
+
+	fields          map[string]*widget.Check // Par champ de searchFields
+	from, to, place *widget.Entry
+	periodError     *widget.Label // Affiché quand une borne de la période est invalide
+
+	content *fyne.Container
+}
+
+// newFilterPanel crée le menu des filtres lié au store, masqué
+func newFilterPanel(store *Store) *FilterPanel {
+	p := &FilterPanel{fields: map[string]*widget.Check{}}
+
+	checks := container.NewVBox()
+	for _, f := range searchFields {
+		label := lang.L(f.Label)
+		check := widget.NewCheckWithData(label, store.Fields[f.Field])
+		store.Facets[f.Field].AddListener(binding.NewDataListener(func() {
+			n, _ := store.Facets[f.Field].Get()
+			check.SetText(fmt.Sprintf("%s (%d)", label, n))
+		}))
+		p.fields[f.Field] = check
+		checks.Add(check)
+	}
+
+	p.from = widget.NewEntryWithData(store.From)
+	p.from.SetPlaceHolder(lang.L("Du (AAAA ou JJ-MM-AAAA)"))
+	p.from.Validator = dateBoundValidator(false)
+	p.to = widget.NewEntryWithData(store.To)
+	p.to.SetPlaceHolder(lang.L("Au (AAAA ou JJ-MM-AAAA)"))
+	p.to.Validator = dateBoundValidator(true)
+	p.place = widget.NewEntryWithData(store.Place)
+	p.place.SetPlaceHolder(lang.L("Lieu (optionnel)"))
+
+	// Une borne invalide est ignorée par la recherche : le menu le signale
+	p.periodError = widget.NewLabel(lang.L("Période ignorée : date invalide (AAAA ou JJ-MM-AAAA)"))
+	p.periodError.Importance = widget.DangerImportance
+	p.periodError.Hide()
+	checkPeriod := binding.NewDataListener(func() {
+		from, _ := store.From.Get()
+		to, _ := store.To.Get()
+		if p.from.Validator(from) != nil || p.to.Validator(to) != nil {
+			p.periodError.Show()
+		} else {
+			p.periodError.Hide()
+		}
+	})
+	store.From.AddListener(checkPeriod)
+	store.To.AddListener(checkPeriod)
+
+	// Favoris : export et import en JSON
+	exportFavs := widget.NewButtonWithIcon(lang.L("Exporter"), theme.DocumentSaveIcon(), func() {
+		if p.OnExportFavorites != nil {
+			p.OnExportFavorites()
+		}
+	})
+	importFavs := widget.NewButtonWithIcon(lang.L("Importer"), theme.FolderOpenIcon(), func() {
+		if p.OnImportFavorites != nil {
+			p.OnImportFavorites()
+		}
+	})
+
+	p.content = createCard(container.NewVBox(
+		widget.NewLabelWithStyle(lang.L("Filtrer par :"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewSeparator(),
+		checks,
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle(lang.L("A joué entre :"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		container.NewGridWithColumns(3, p.from, p.to, p.place),
+		p.periodError,
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle(lang.L("Favoris :"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		container.NewHBox(exportFavs, importFavs),
+	))
+	p.content.Hide()
+	return p
+}
+
+// dateBoundValidator accepte une borne de période vide, AAAA ou JJ-MM-AAAA
+// end indique la borne de fin
+func dateBoundValidator(end bool) fyne.StringValidator {
+	return func(s string) error {
+		if _, err := api.ParseDateBound(s, end); err != nil {
+			return errors.New(lang.L("date attendue : AAAA ou JJ-MM-AAAA"))
+		}
+		return nil
+	}
+}
+
+// Toggle affiche ou masque le menu
+func (p *FilterPanel) Toggle() {
+	if p.content.Visible() {
+		p.content.Hide()
+	} else {
+		p.content.Show()
+	}
+}
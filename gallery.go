@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	api "groupie/models"
+)
+
+// newGallery crée la vue grille des artistes : photo, nom et année de création
+// Le nombre de colonnes s'adapte à la largeur de la fenêtre et les photos
+// ne sont chargées que lorsque leur card devient visible
+func newGallery(artists func() []api.Artist, onSelected func(api.Artist)) *widget.GridWrap {
+	var gallery *widget.GridWrap
+	gallery = widget.NewGridWrap(
+		func() int { return len(artists()) },
+		func() fyne.CanvasObject {
+			img := newArtistImage(galleryThumbSize)
+
+			name := widget.NewLabelWithStyle("Nom", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+			name.Truncation = fyne.TextTruncateEllipsis
+			year := widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+
+			return createCard(container.NewVBox(img, name, year))
+		},
+		func(i widget.GridWrapItemID, o fyne.CanvasObject) {
+			list := artists()
+			if i >= len(list) {
+				return
+			}
+			artist := list[i]
+
+			content := o.(*fyne.Container).Objects[1].(*fyne.Container).Objects[0].(*fyne.Container)
+			img := content.Objects[0].(*artistImage)
+			content.Objects[1].(*widget.Label).SetText(artist.Name)
+			content.Objects[2].(*widget.Label).SetText(fmt.Sprint(artist.CreationDate))
+
+			img.Load(artist.Image, galleryThumbSize)
+		},
+	)
+	gallery.OnSelected = func(id widget.GridWrapItemID) {
+		list := artists()
+		gallery.UnselectAll()
+		if id < len(list) {
+			onSelected(list[id])
+		}
+	}
+	return gallery
+}
@@ -0,0 +1,217 @@
+// Package geo trouve les coordonnées des lieux de concerts avec Nominatim ou Photon
+// Les lieux trouvés, comme les lieux introuvables, sont gardés en mémoire : chaque lieu
+// n'est demandé qu'une fois, et jamais plus d'une requête par seconde n'est envoyée
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	api "groupie/models"
+)
+
+// Result contient les coordonnées d'un lieu, telles que renvoyées par le géocodeur
+type Result struct {
+	Lat string `json:"lat"`
+	Lon string `json:"lon"`
+}
+
+// Géocodeurs disponibles
+const (
+	Nominatim = "Nominatim"
+	Photon    = "Photon"
+)
+
+// Services liste les géocodeurs, dans l'ordre proposé aux utilisateurs
+var Services = []string{Nominatim, Photon}
+
+// ErrNotFound est renvoyée quand le géocodeur ne connaît pas le lieu
+var ErrNotFound = errors.New("lieu introuvable")
+
+// client envoie les requêtes aux géocodeurs
+var client = api.NewClient(api.DefaultTimeout)
+
+// Géocodeur choisi, lu depuis les goroutines de chargement
+var (
+	serviceMu sync.Mutex
+	service   = Nominatim
+)
+
+// Coordonnées déjà trouvées et lieux introuvables, par nom de lieu en minuscules
+var (
+	cacheMu  sync.Mutex
+	cache    = map[string]Result{}
+	notFound = map[string]bool{}
+)
+
+// Délai minimal entre deux requêtes : Nominatim n'en accepte qu'une par seconde
+var (
+	limitMu     sync.Mutex
+	interval    = time.Second
+	lastRequest time.Time
+)
+
+// SetService choisit le géocodeur par son nom ; un nom inconnu est ignoré
+// Les lieux introuvables pour l'ancien géocodeur seront demandés au nouveau
+func SetService(name string) {
+	for _, s := range Services {
+		if s == name {
+			serviceMu.Lock()
+			changed := service != s
+			service = s
+			serviceMu.Unlock()
+			if changed {
+				cacheMu.Lock()
+				notFound = map[string]bool{}
+				cacheMu.Unlock()
+			}
+		}
+	}
+}
+
+// SetTimeout change le délai maximal des requêtes au géocodeur
+func SetTimeout(d time.Duration) {
+	client.SetTimeout(d)
+}
+
+// SetTransport remplace le transport des requêtes, ex: par une cassette (nil : transport par défaut)
+func SetTransport(rt http.RoundTripper) {
+	client.SetTransport(rt)
+}
+
+// Known renvoie les coordonnées d'un lieu déjà géocodé, sans requête
+func Known(place string) (float64, float64, bool) {
+	cacheMu.Lock()
+	res, ok := cache[strings.ToLower(place)]
+	cacheMu.Unlock()
+	if !ok {
+		return 0, 0, false
+	}
+	lat, errLat := strconv.ParseFloat(res.Lat, 64)
+	lon, errLon := strconv.ParseFloat(res.Lon, 64)
+	return lat, lon, errLat == nil && errLon == nil
+}
+
+// Lookup trouve la latitude et la longitude d'un lieu, ex: "paris, france"
+func Lookup(place string) (string, string, error) {
+	key := strings.ToLower(place)
+	cacheMu.Lock()
+	cached, ok := cache[key]
+	missing := notFound[key]
+	cacheMu.Unlock()
+	if ok {
+		return cached.Lat, cached.Lon, nil
+	}
+	if missing {
+		return "", "", ErrNotFound
+	}
+
+	serviceMu.Lock()
+	current := service
+	serviceMu.Unlock()
+
+	var res Result
+	var err error
+	if current == Photon {
+		res, err = photon(place)
+	} else {
+		res, err = nominatim(place)
+	}
+	if errors.Is(err, ErrNotFound) {
+		// Lieu introuvable : inutile de le redemander ; une erreur réseau, elle, sera retentée
+		cacheMu.Lock()
+		notFound[key] = true
+		cacheMu.Unlock()
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	cacheMu.Lock()
+	cache[key] = res
+	cacheMu.Unlock()
+	return res.Lat, res.Lon, nil
+}
+
+// Prefetch géocode des lieux l'un après l'autre, ex: en arrière-plan au démarrage d'un serveur
+// Les coordonnées sont ensuite disponibles avec Known ; les erreurs sont ignorées
+func Prefetch(places []string) {
+	for _, place := range places {
+		Lookup(place)
+	}
+}
+
+// wait attend que le délai depuis la requête précédente soit écoulé
+// Les requêtes simultanées passent ainsi l'une après l'autre
+func wait() {
+	limitMu.Lock()
+	defer limitMu.Unlock()
+	if d := time.Until(lastRequest.Add(interval)); d > 0 {
+		time.Sleep(d)
+	}
+	lastRequest = time.Now()
+}
+
+// get envoie une requête au géocodeur, qui demande un User-Agent
+// Une réponse en erreur (ex: 429 quand le débit est dépassé) n'est pas un lieu introuvable
+func get(url string) (*http.Response, error) {
+	wait()
+	req, _ := http.NewRequest("GET", url, nil)
+	req.Header.Set("User-Agent", "GroupieTracker/1.0")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &api.StatusError{Status: resp.Status}
+	}
+	return resp, nil
+}
+
+// nominatim géocode un lieu avec Nominatim (OpenStreetMap)
+func nominatim(place string) (Result, error) {
+	resp, err := get(fmt.Sprintf("https://nominatim.openstreetmap.org/search?q=%s&format=json&limit=1", url.QueryEscape(place)))
+	if err != nil {
+		return Result{}, err
+	}
+	defer resp.Body.Close()
+
+	var res []Result
+	if json.NewDecoder(resp.Body).Decode(&res) != nil || len(res) == 0 {
+		return Result{}, ErrNotFound
+	}
+	return res[0], nil
+}
+
+// photon géocode un lieu avec Photon (Komoot), qui renvoie du GeoJSON
+func photon(place string) (Result, error) {
+	resp, err := get(fmt.Sprintf("https://photon.komoot.io/api/?q=%s&limit=1", url.QueryEscape(place)))
+	if err != nil {
+		return Result{}, err
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		Features []struct {
+			Geometry struct {
+				Coordinates []float64 `json:"coordinates"` // Longitude puis latitude
+			} `json:"geometry"`
+		} `json:"features"`
+	}
+	if json.NewDecoder(resp.Body).Decode(&res) != nil || len(res.Features) == 0 || len(res.Features[0].Geometry.Coordinates) < 2 {
+		return Result{}, ErrNotFound
+	}
+	coords := res.Features[0].Geometry.Coordinates
+	return Result{
+		Lat: strconv.FormatFloat(coords[1], 'f', -1, 64),
+		Lon: strconv.FormatFloat(coords[0], 'f', -1, 64),
+	}, nil
+}
@@ -0,0 +1,128 @@
+package geo
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"groupie/cassette"
+)
+
+// useCassette rejoue une cassette de testdata sur le client du géocodeur, avec un cache vide
+func useCassette(t *testing.T, name string) {
+	t.Helper()
+	rec, err := cassette.New("testdata/"+name, cassette.Replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetTransport(rec)
+
+	cacheMu.Lock()
+	cache = map[string]Result{}
+	notFound = map[string]bool{}
+	cacheMu.Unlock()
+	setInterval(t, 0)
+	t.Cleanup(func() { SetTransport(nil) })
+}
+
+// setInterval change le délai entre deux requêtes le temps du test
+func setInterval(t *testing.T, d time.Duration) {
+	limitMu.Lock()
+	previous := interval
+	interval = d
+	limitMu.Unlock()
+	t.Cleanup(func() {
+		limitMu.Lock()
+		interval = previous
+		limitMu.Unlock()
+	})
+}
+
+// roundTripFunc transforme une fonction en transport HTTP
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestLookup(t *testing.T) {
+	useCassette(t, "geocode.json")
+
+	lat, lon, err := Lookup("paris, france")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lat != "48.8534951" || lon != "2.3483915" {
+		t.Errorf("coordonnées = %s, %s", lat, lon)
+	}
+
+	// Le résultat est mis en cache, quelle que soit la casse
+	if lat, lon, ok := Known("Paris, France"); !ok || lat < 48 || lat > 49 || lon < 2 || lon > 3 {
+		t.Errorf("Known = %v, %v, %v", lat, lon, ok)
+	}
+}
+
+func TestLookupNotFound(t *testing.T) {
+	useCassette(t, "geocode.json")
+
+	if _, _, err := Lookup("atlantis, nowhere"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("erreur = %v, attendu %v", err, ErrNotFound)
+	}
+	if _, _, ok := Known("atlantis, nowhere"); ok {
+		t.Error("un lieu introuvable ne doit pas avoir de coordonnées")
+	}
+
+	// Le lieu introuvable n'est pas redemandé au géocodeur
+	SetTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("requête inattendue : %s", req.URL)
+		return nil, errors.New("pas de réseau")
+	}))
+	if _, _, err := Lookup("Atlantis, Nowhere"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("erreur en cache = %v, attendu %v", err, ErrNotFound)
+	}
+}
+
+func TestLookupErrorIsRetried(t *testing.T) {
+	useCassette(t, "geocode.json")
+	SetTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{Status: "429 Too Many Requests", StatusCode: http.StatusTooManyRequests, Body: http.NoBody}, nil
+	}))
+
+	if _, _, err := Lookup("paris, france"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("erreur = %v, attendu l'erreur HTTP", err)
+	}
+	cacheMu.Lock()
+	missing := notFound["paris, france"]
+	cacheMu.Unlock()
+	if missing {
+		t.Error("une erreur du géocodeur ne doit pas marquer le lieu introuvable")
+	}
+}
+
+func TestRequestsAreSpaced(t *testing.T) {
+	useCassette(t, "geocode.json")
+	setInterval(t, 100*time.Millisecond)
+
+	start := time.Now()
+	Prefetch([]string{"paris, france", "atlantis, nowhere", "paris, france"})
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("deux requêtes en %v, attendu au moins %v d'écart", elapsed, 100*time.Millisecond)
+	}
+	if _, _, ok := Known("paris, france"); !ok {
+		t.Error("Prefetch doit garder les coordonnées trouvées")
+	}
+}
+
+func TestPhoton(t *testing.T) {
+	useCassette(t, "geocode.json")
+	SetService(Photon)
+	t.Cleanup(func() { SetService(Nominatim) })
+
+	// Photon renvoie la longitude avant la latitude
+	lat, lon, err := Lookup("tokyo, japan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lat != "35.6895014" || lon != "139.6917064" {
+		t.Errorf("coordonnées Photon = %s, %s", lat, lon)
+	}
+}
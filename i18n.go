@@ -0,0 +1,121 @@
+package main
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/lang"
+
+	"groupie/geo"
+	api "groupie/models"
+)
+
+// Catalogues de traduction : le texte français sert de clé, comme le texte anglais
+// dans les catalogues de Fyne ; fr.json et en.json contiennent toutes les clés
+//
+//go:embed translations
+var translations embed.FS
+
+// Clé des préférences contenant la langue choisie ("" : langue du système)
+const prefLanguage = "language"
+
+// Langues disponibles, la première sert quand celle du système n'est pas traduite
+var languages = []string{"en", "fr"}
+
+// languageNames donne le nom de chaque langue dans cette langue
+var languageNames = map[string]string{"en": "English", "fr": "Français"}
+
+// systemLanguage renvoie la langue du système si elle est traduite, sinon l'anglais
+func systemLanguage() string {
+	code := lang.SystemLocale().LanguageString()
+	if _, ok := languageNames[code]; ok {
+		return code
+	}
+	return languages[0]
+}
+
+// setLanguage charge le catalogue d'une langue ("" : celle du système) et renvoie la langue appliquée
+// Fyne choisit toujours la langue du système : le catalogue choisi est donc chargé sous
+// cette langue, à la place du précédent. Les textes propres à Fyne (boîtes de dialogue...)
+// et les règles de pluriel (0 singulier ou pluriel) restent ceux de la langue du système
+func setLanguage(code string) string {
+	if _, ok := languageNames[code]; !ok {
+		code = systemLanguage()
+	}
+	data, err := translations.ReadFile("translations/" + code + ".json")
+	if err != nil {
+		fyne.LogError("Catalogue introuvable", err)
+		return code
+	}
+	system := fyne.Locale(lang.SystemLocale().LanguageString())
+	if err := lang.AddTranslationsForLocale(data, system); err != nil {
+		fyne.LogError("Catalogue illisible", err)
+	}
+	return code
+}
+
+// plural traduit un format contenant un %d selon le nombre, ex: plural("%d artiste(s)", 2)
+func plural(format string, n int) string {
+	return fmt.Sprintf(lang.N(format, n), n)
+}
+
+// sortLabel renvoie le libellé traduit d'un ordre de tri
+func sortLabel(o api.SortOrder) string {
+	return lang.L(o.Label())
+}
+
+// columnLabel renvoie l'en-tête traduit d'une colonne d'export
+func columnLabel(c api.Column) string {
+	return lang.L(c.Label())
+}
+
+// sortOrderFromLabel retrouve un ordre de tri depuis son libellé traduit
+// Renvoie SortNameAsc si le libellé est inconnu
+func sortOrderFromLabel(label string) api.SortOrder {
+	for _, o := range api.SortOrders {
+		if sortLabel(o) == label {
+			return o
+		}
+	}
+	return api.SortNameAsc
+}
+
+// errorText renvoie le message d'une erreur, avec l'erreur de l'API ou du géocodeur qu'elle contient traduite
+// Les paquets models, geo, web et la ligne de commande gardent leurs messages en français
+func errorText(err error) string {
+	text := err.Error()
+	var ce *api.CatalogError
+	if errors.As(err, &ce) {
+		format := lang.L("artistes indisponibles : %s")
+		if ce.Part == api.CatalogConcerts {
+			format = lang.L("concerts indisponibles : %s")
+		}
+		return strings.Replace(text, ce.Error(), fmt.Sprintf(format, errorText(ce.Err)), 1)
+	}
+	if se, ok := api.AsStatusError(err); ok {
+		text = strings.Replace(text, se.Error(), fmt.Sprintf(lang.L("erreur API : %s"), se.Status), 1)
+	}
+	if errors.Is(err, geo.ErrNotFound) {
+		text = strings.Replace(text, geo.ErrNotFound.Error(), lang.L("lieu introuvable"), 1)
+	}
+	return text
+}
+
+// formatDate formate une date selon la langue : 14/12/1973 ou Dec 14, 1973
+func formatDate(t time.Time) string {
+	return t.Format(lang.X("date.layout", "02/01/2006"))
+}
+
+// formatAPIDate reformate une date de l'API (14-12-1973) selon la langue
+// Une date illisible est renvoyée telle quelle
+func formatAPIDate(s string) string {
+	t, err := api.ParseConcertDate(s)
+	if err != nil {
+		return s
+	}
+	return formatDate(t)
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	api "groupie/models"
+)
+
+// readTranslations lit un catalogue de traduction embarqué
+func readTranslations(t *testing.T, code string) map[string]any {
+	t.Helper()
+	data, err := translations.ReadFile("translations/" + code + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var catalog map[string]any
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		t.Fatalf("%s.json : %v", code, err)
+	}
+	return catalog
+}
+
+// sourceKeys renvoie les textes traduits dans le code : arguments littéraux de lang.L, lang.N,
+// lang.X et plural, ainsi que les tables de libellés traduites à l'affichage
+// (dont les libellés français du paquet models)
+func sourceKeys(t *testing.T) map[string]bool {
+	t.Helper()
+	files, _ := filepath.Glob("*.go")
+	keys := map[string]bool{}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			switch fn := call.Fun.(type) {
+			case *ast.SelectorExpr:
+				if id, ok := fn.X.(*ast.Ident); !ok || id.Name != "lang" {
+					return true
+				}
+			case *ast.Ident:
+				if fn.Name != "plural" {
+					return true
+				}
+			default:
+				return true
+			}
+			if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				key, _ := strconv.Unquote(lit.Value)
+				keys[key] = true
+			}
+			return true
+		})
+	}
+	for _, name := range api.MonthNames {
+		keys[name] = true
+	}
+	keys[api.GapDaysFormat] = true
+	keys[api.GapMonthsFormat] = true
+	for _, name := range weekdayNames {
+		keys[name] = true
+	}
+	for _, f := range searchFields {
+		keys[f.Label] = true
+	}
+	for _, o := range api.SortOrders {
+		keys[o.Label()] = true
+	}
+	for _, c := range api.Columns {
+		if c != api.ColumnID { // Clé réservée par go-i18n, identique dans les deux langues
+			keys[c.Label()] = true
+		}
+	}
+	return keys
+}
+
+func TestCatalogsComplete(t *testing.T) {
+	fr, en := readTranslations(t, "fr"), readTranslations(t, "en")
+
+	// Chaque catalogue remplace entièrement l'autre : ils ont les mêmes clés, de même forme
+	for key, value := range fr {
+		other, ok := en[key]
+		if !ok {
+			t.Errorf("%q absent de en.json", key)
+			continue
+		}
+		_, frPlural := value.(map[string]any)
+		_, enPlural := other.(map[string]any)
+		if frPlural != enPlural {
+			t.Errorf("%q : formes différentes entre fr.json et en.json", key)
+		}
+	}
+	for key := range en {
+		if _, ok := fr[key]; !ok {
+			t.Errorf("%q absent de fr.json", key)
+		}
+	}
+
+	for key := range sourceKeys(t) {
+		if _, ok := fr[key]; !ok {
+			t.Errorf("%q utilisé dans le code mais absent des catalogues", key)
+		}
+	}
+}
+
+func TestSetLanguage(t *testing.T) {
+	test.NewTempApp(t)
+	t.Cleanup(func() { setLanguage("fr") })
+	date := time.Date(1973, time.December, 14, 0, 0, 0, 0, time.UTC)
+
+	if got := setLanguage("en"); got != "en" {
+		t.Fatalf("langue appliquée = %q", got)
+	}
+	if got := plural("%d artiste(s)", 1); got != "1 artist" {
+		t.Errorf("pluriel anglais = %q", got)
+	}
+	if got := plural("%d artiste(s)", 3); got != "3 artists" {
+		t.Errorf("pluriel anglais = %q", got)
+	}
+	if got := formatDate(date); got != "Dec 14, 1973" {
+		t.Errorf("date anglaise = %q", got)
+	}
+
+	setLanguage("fr")
+	if got := plural("%d artiste(s)", 1); got != "1 artiste" {
+		t.Errorf("pluriel français = %q", got)
+	}
+	if got := plural("%d artiste(s)", 2); got != "2 artistes" {
+		t.Errorf("pluriel français = %q", got)
+	}
+	if got := formatAPIDate("14-12-1973"); got != "14/12/1973" {
+		t.Errorf("date française = %q", got)
+	}
+	if got := formatAPIDate("pas une date"); got != "pas une date" {
+		t.Errorf("date illisible = %q", got)
+	}
+
+	// Une langue inconnue suit le système
+	if got := setLanguage("xx"); got != systemLanguage() {
+		t.Errorf("langue inconnue : %q, attendu %q", got, systemLanguage())
+	}
+}
+
+func TestModelsStayFrench(t *testing.T) {
+	test.NewTempApp(t)
+	setLanguage("en")
+	t.Cleanup(func() { setLanguage("fr") })
+
+	// Le paquet models, utilisé aussi par la ligne de commande et le serveur web, ne traduit rien
+	if got := api.SortCreation.Label(); got != "Année de création" {
+		t.Errorf("libellé du tri = %q", got)
+	}
+	stats := api.ComputeStats([]api.Artist{{Name: "Queen", CreationDate: 1970, Members: []string{"Freddie Mercury"}}}, nil)
+	if got := stats.Decades[0].Label; got != "Années 1970" {
+		t.Errorf("libellé de décennie = %q", got)
+	}
+	err := &api.CatalogError{Part: api.CatalogArtists, Err: &api.StatusError{Status: "404 Not Found"}}
+	if got := err.Error(); got != "artistes indisponibles : erreur API : 404 Not Found" {
+		t.Errorf("erreur = %q", got)
+	}
+
+	// L'interface graphique les traduit
+	if got := sortLabel(api.SortCreation); got != "Year of creation" {
+		t.Errorf("libellé traduit du tri = %q", got)
+	}
+	if got := sortOrderFromLabel("Year of creation"); got != api.SortCreation {
+		t.Errorf("tri retrouvé = %q", got)
+	}
+	if got := translateBuckets(chartMembers, stats.Members)[0].Label; got != "1 member" {
+		t.Errorf("libellé traduit = %q", got)
+	}
+	if got := errorText(err); got != "artists unavailable: API error: 404 Not Found" {
+		t.Errorf("erreur traduite = %q", got)
+	}
+}
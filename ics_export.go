@@ -0,0 +1,39 @@
+package main
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/storage"
+
+	"groupie/geo"
+	api "groupie/models"
+)
+
+// saveICS propose d'enregistrer les concerts des artistes dans un fichier .ics
+// Les coordonnées déjà trouvées par le géocodage sont ajoutées (GEO)
+func saveICS(w fyne.Window, fileName string, artists []api.Artist, concerts map[int][]api.Concert) {
+	var events []api.CalendarEvent
+	for _, a := range artists {
+		events = append(events, api.ConcertEvents(a, concerts[a.ID], geo.Known)...)
+	}
+	if len(events) == 0 {
+		dialog.ShowInformation(lang.L("Export .ics"), lang.L("Aucun concert à exporter"), w)
+		return
+	}
+
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil || writer == nil {
+			return
+		}
+		defer writer.Close()
+		if err := api.WriteICS(writer, events, time.Now()); err != nil {
+			dialog.ShowError(err, w)
+		}
+	}, w)
+	save.SetFileName(fileName)
+	save.SetFilter(storage.NewExtensionFileFilter([]string{".ics"}))
+	save.Show()
+}
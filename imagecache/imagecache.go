@@ -0,0 +1,415 @@
+// Package imagecache télécharge les images des artistes en arrière-plan,
+// les garde sur disque (originaux et miniatures) et les décode hors du thread UI.
+package imagecache
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // Décodeur GIF
+	_ "image/jpeg" // Décodeur JPEG
+	"image/png"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/image/draw"
+)
+
+// Original demande l'image en taille réelle
+const Original = 0
+
+// DefaultMemoryBytes est la taille maximale par défaut des images décodées gardées en mémoire
+const DefaultMemoryBytes = 64 << 20
+
+// Limites d'une image téléchargée, vérifiées avant de la décoder entièrement :
+// un fichier trop lourd n'est pas lu jusqu'au bout, une image trop grande n'est pas décodée
+const (
+	MaxDownloadBytes = 10 << 20
+	MaxPixels        = DefaultMemoryBytes / 4 // Une fois décodée, l'image tient dans la mémoire par défaut
+)
+
+// ErrTooLarge est renvoyée pour une image qui dépasse MaxDownloadBytes ou MaxPixels
+var ErrTooLarge = errors.New("image trop grande")
+
+// Callback reçoit l'image décodée ou une erreur.
+// Il est toujours appelé depuis un goroutine du service, après le retour de Get,
+// jamais depuis le thread UI, même quand l'image est déjà en mémoire.
+type Callback func(image.Image, error)
+
+// Doer envoie les requêtes HTTP, ex: *http.Client
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// request identifie une image demandée : URL et taille maximale (0 = originale)
+type request struct {
+	url  string
+	size int
+}
+
+// decoded est une image décodée gardée en mémoire
+type decoded struct {
+	req    request
+	img    image.Image
+	bytes  int64
+	loaded time.Time
+}
+
+// Service télécharge et décode les images avec un pool de workers.
+// Les originaux sont stockés dans <dir>/originals et les miniatures
+// dans <dir>/thumbs/<taille>, nommés par le hash SHA-256 de l'URL.
+type Service struct {
+	dir    string
+	client Doer
+	jobs   chan request
+
+	mu        sync.Mutex
+	memory    map[request]*list.Element // Images déjà décodées, éléments de lru
+	lru       *list.List                // Images décodées, de la plus récemment utilisée à la plus ancienne
+	memBytes  int64                     // Taille des images décodées en mémoire
+	maxMemory int64                     // Taille maximale des images décodées en mémoire
+	pending   map[request][]Callback    // Demandes en cours, regroupées par image
+	maxBytes  int64                     // Taille maximale du cache disque (0 = illimitée)
+	ttl       time.Duration             // Durée de vie d'un fichier ou d'une image en mémoire (0 = illimitée)
+}
+
+// New crée un service d'images qui stocke ses fichiers dans dir
+// et lance workers goroutines de téléchargement
+func New(dir string, workers int, client Doer) *Service {
+	if workers < 1 {
+		workers = 1
+	}
+	s := &Service{
+		dir:       dir,
+		client:    client,
+		jobs:      make(chan request, 256),
+		memory:    map[request]*list.Element{},
+		lru:       list.New(),
+		maxMemory: DefaultMemoryBytes,
+		pending:   map[request][]Callback{},
+	}
+	for i := 0; i < workers; i++ {
+		go s.worker()
+	}
+	return s
+}
+
+// SetLimits règle la taille maximale du cache disque et la durée de vie de ses fichiers
+// (0 = illimitée). Les fichiers expirés sont téléchargés à nouveau ; Prune applique la taille
+// Les images en mémoire suivent la même durée de vie et ne dépassent ni maxBytes ni DefaultMemoryBytes
+func (s *Service) SetLimits(maxBytes int64, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxBytes, s.ttl = maxBytes, ttl
+	s.maxMemory = DefaultMemoryBytes
+	if maxBytes > 0 && maxBytes < s.maxMemory {
+		s.maxMemory = maxBytes
+	}
+	s.evict()
+}
+
+// Prune supprime les fichiers expirés du cache disque, puis les plus anciens
+// tant que le cache dépasse sa taille maximale
+func (s *Service) Prune() error {
+	s.mu.Lock()
+	maxBytes, ttl := s.maxBytes, s.ttl
+	s.mu.Unlock()
+
+	type cached struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var files []cached
+	var total int64
+	err := filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if ttl > 0 && time.Since(info.ModTime()) > ttl {
+			return os.Remove(path)
+		}
+		files = append(files, cached{path, info.Size(), info.ModTime()})
+		total += info.Size()
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil // Rien n'a encore été mis en cache
+	}
+	if err != nil || maxBytes <= 0 {
+		return err
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for _, f := range files {
+		if total <= maxBytes {
+			break
+		}
+		if err := os.Remove(f.path); err != nil {
+			return err
+		}
+		total -= f.size
+	}
+	return nil
+}
+
+// Get demande l'image de url, réduite pour tenir dans un carré size×size
+// (Original pour la taille réelle). done est appelé une fois l'image prête.
+func (s *Service) Get(url string, size int, done Callback) {
+	if url == "" {
+		go done(nil, errors.New("URL d'image vide"))
+		return
+	}
+	req := request{url: url, size: size}
+
+	s.mu.Lock()
+	if img, ok := s.remembered(req); ok {
+		s.mu.Unlock()
+		go done(img, nil)
+		return
+	}
+	waiting, inFlight := s.pending[req]
+	s.pending[req] = append(waiting, done)
+	s.mu.Unlock()
+
+	// Une demande identique est déjà en cours : on attend son résultat
+	if !inFlight {
+		go func() { s.jobs <- req }()
+	}
+}
+
+// worker traite les demandes une par une
+func (s *Service) worker() {
+	for req := range s.jobs {
+		img, err := s.load(req)
+
+		s.mu.Lock()
+		if err == nil {
+			s.remember(req, img)
+		}
+		callbacks := s.pending[req]
+		delete(s.pending, req)
+		s.mu.Unlock()
+
+		for _, cb := range callbacks {
+			cb(img, err)
+		}
+	}
+}
+
+// remembered renvoie une image décodée gardée en mémoire, si elle n'a pas expiré
+// s.mu doit être verrouillé
+func (s *Service) remembered(req request) (image.Image, bool) {
+	e, ok := s.memory[req]
+	if !ok {
+		return nil, false
+	}
+	d := e.Value.(*decoded)
+	if s.ttl > 0 && time.Since(d.loaded) > s.ttl {
+		s.forget(e)
+		return nil, false
+	}
+	s.lru.MoveToFront(e)
+	return d.img, true
+}
+
+// remember garde une image décodée en mémoire, en oubliant les moins récemment utilisées
+// au-delà de la taille maximale ; s.mu doit être verrouillé
+func (s *Service) remember(req request, img image.Image) {
+	if e, ok := s.memory[req]; ok {
+		s.forget(e)
+	}
+	b := img.Bounds()
+	d := &decoded{req: req, img: img, bytes: int64(b.Dx()) * int64(b.Dy()) * 4, loaded: time.Now()}
+	if d.bytes > s.maxMemory {
+		return // Trop grande pour la mémoire : relue depuis le disque
+	}
+	s.memory[req] = s.lru.PushFront(d)
+	s.memBytes += d.bytes
+	s.evict()
+}
+
+// evict oublie les images les moins récemment utilisées jusqu'à revenir sous la taille maximale
+// s.mu doit être verrouillé
+func (s *Service) evict() {
+	for s.memBytes > s.maxMemory && s.lru.Len() > 0 {
+		s.forget(s.lru.Back())
+	}
+}
+
+// forget retire une image de la mémoire ; s.mu doit être verrouillé
+func (s *Service) forget(e *list.Element) {
+	d := s.lru.Remove(e).(*decoded)
+	delete(s.memory, d.req)
+	s.memBytes -= d.bytes
+}
+
+// load renvoie l'image demandée depuis le disque, ou la télécharge et la met en cache
+func (s *Service) load(req request) (image.Image, error) {
+	if req.size == Original {
+		return s.original(req.url)
+	}
+
+	// Miniature déjà générée
+	thumbPath := s.path(filepath.Join("thumbs", fmt.Sprint(req.size)), req.url)
+	if img, err := s.decodeFresh(thumbPath); err == nil {
+		return img, nil
+	}
+
+	orig, err := s.original(req.url)
+	if err != nil {
+		return nil, err
+	}
+	thumb := Thumbnail(orig, req.size)
+
+	// Une miniature non sauvegardée sera simplement régénérée
+	var buf bytes.Buffer
+	if png.Encode(&buf, thumb) == nil {
+		_ = writeFile(thumbPath, buf.Bytes())
+	}
+	return thumb, nil
+}
+
+// original renvoie l'image en taille réelle, depuis le disque ou le réseau
+func (s *Service) original(url string) (image.Image, error) {
+	path := s.path("originals", url)
+	if img, err := s.decodeFresh(path); err == nil {
+		return img, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("Erreur serveur: " + resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxDownloadBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxDownloadBytes {
+		return nil, ErrTooLarge
+	}
+	// Les dimensions sont lues dans l'en-tête, sans décoder les pixels
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > MaxPixels {
+		return nil, ErrTooLarge
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	// Le cache disque est une optimisation : une erreur d'écriture n'est pas bloquante
+	_ = writeFile(path, data)
+	return img, nil
+}
+
+// path renvoie le chemin du fichier de cache de url dans le sous-dossier kind
+func (s *Service) path(kind, url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(s.dir, kind, hex.EncodeToString(sum[:]))
+}
+
+// Thumbnail réduit img pour qu'il tienne dans un carré size×size
+// en conservant ses proportions. Une image plus petite est renvoyée telle quelle.
+func Thumbnail(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= size && h <= size {
+		return img
+	}
+	if w >= h {
+		h = h * size / w
+		w = size
+	} else {
+		w = w * size / h
+		h = size
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.ApproxBiLinear.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// decodeFresh décode une image du cache disque si elle n'a pas expiré
+func (s *Service) decodeFresh(path string) (image.Image, error) {
+	s.mu.Lock()
+	ttl := s.ttl
+	s.mu.Unlock()
+	if ttl > 0 {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if time.Since(info.ModTime()) > ttl {
+			return nil, errors.New("image expirée")
+		}
+	}
+	return decodeFile(path)
+}
+
+// decodeFile décode une image stockée sur disque
+func decodeFile(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	return img, err
+}
+
+// writeFile écrit data dans path en créant les dossiers nécessaires
+// Le fichier est écrit à côté puis renommé pour ne jamais lire un fichier partiel
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
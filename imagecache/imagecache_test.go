@@ -0,0 +1,184 @@
+package imagecache_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"groupie/imagecache"
+)
+
+// writeCached crée un fichier de cache de size octets, modifié il y a age
+func writeCached(t *testing.T, dir, name string, size int, age time.Duration) string {
+	t.Helper()
+	path := filepath.Join(dir, "originals", name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	when := time.Now().Add(-age)
+	if err := os.Chtimes(path, when, when); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestPrune(t *testing.T) {
+	dir := t.TempDir()
+	expired := writeCached(t, dir, "expired", 10, 48*time.Hour)
+	oldest := writeCached(t, dir, "oldest", 100, 3*time.Hour)
+	older := writeCached(t, dir, "older", 100, 2*time.Hour)
+	recent := writeCached(t, dir, "recent", 100, time.Hour)
+
+	s := imagecache.New(dir, 1, http.DefaultClient)
+	s.SetLimits(250, 24*time.Hour)
+	if err := s.Prune(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Le fichier expiré, puis le plus ancien pour revenir sous 250 octets
+	if exists(expired) || exists(oldest) {
+		t.Error("fichiers expirés ou les plus anciens non supprimés")
+	}
+	if !exists(older) || !exists(recent) {
+		t.Error("fichiers récents supprimés")
+	}
+}
+
+func TestPruneEmptyCache(t *testing.T) {
+	s := imagecache.New(filepath.Join(t.TempDir(), "absent"), 1, http.DefaultClient)
+	s.SetLimits(1, time.Hour)
+	if err := s.Prune(); err != nil {
+		t.Errorf("cache absent : %v", err)
+	}
+}
+
+// newImageServer sert une image PNG 4×4 par chemin et compte les téléchargements
+func newImageServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var downloads atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads.Add(1)
+		png.Encode(w, image.NewRGBA(image.Rect(0, 0, 4, 4)))
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &downloads
+}
+
+// get demande une image et attend le callback
+func get(t *testing.T, s *imagecache.Service, url string) (image.Image, error) {
+	t.Helper()
+	type result struct {
+		img image.Image
+		err error
+	}
+	done := make(chan result, 1)
+	s.Get(url, imagecache.Original, func(img image.Image, err error) { done <- result{img, err} })
+	select {
+	case r := <-done:
+		return r.img, r.err
+	case <-time.After(time.Second):
+		t.Fatalf("image %s non reçue", url)
+		return nil, nil
+	}
+}
+
+func TestGetDeliversAsynchronously(t *testing.T) {
+	ts, _ := newImageServer(t)
+	s := imagecache.New(t.TempDir(), 1, ts.Client())
+	if _, err := get(t, s, ts.URL+"/queen.png"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Image en mémoire ou URL vide : le callback n'est pas appelé pendant Get
+	for _, url := range []string{ts.URL + "/queen.png", ""} {
+		release := make(chan struct{})
+		returned := make(chan struct{})
+		go func() {
+			s.Get(url, imagecache.Original, func(image.Image, error) { <-release })
+			close(returned)
+		}()
+		select {
+		case <-returned:
+		case <-time.After(time.Second):
+			t.Errorf("Get(%q) a attendu le callback", url)
+		}
+		close(release)
+	}
+}
+
+func TestMemoryIsBounded(t *testing.T) {
+	ts, downloads := newImageServer(t)
+	dir := t.TempDir()
+	s := imagecache.New(dir, 1, ts.Client())
+	// Une image 4×4 décodée occupe 64 octets : la mémoire n'en garde qu'une
+	s.SetLimits(100, 0)
+
+	get(t, s, ts.URL+"/queen.png")
+	get(t, s, ts.URL+"/toto.png")
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	// La plus récente est servie par la mémoire, l'autre a été oubliée et retéléchargée
+	get(t, s, ts.URL+"/toto.png")
+	if n := downloads.Load(); n != 2 {
+		t.Errorf("%d téléchargements, attendu 2 : image récente absente de la mémoire", n)
+	}
+	get(t, s, ts.URL+"/queen.png")
+	if n := downloads.Load(); n != 3 {
+		t.Errorf("%d téléchargements, attendu 3 : image ancienne gardée en mémoire", n)
+	}
+}
+
+// hugePNG renvoie un PNG 1×1 dont l'en-tête annonce width×height pixels
+func hugePNG(t *testing.T, width, height uint32) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	// Signature (8 octets), puis le bloc IHDR : longueur, type, largeur, hauteur... et CRC
+	ihdr := data[8+8 : 8+8+13]
+	binary.BigEndian.PutUint32(ihdr[0:4], width)
+	binary.BigEndian.PutUint32(ihdr[4:8], height)
+	binary.BigEndian.PutUint32(data[8+8+13:], crc32.ChecksumIEEE(data[8+4:8+8+13]))
+	return data
+}
+
+func TestRejectsOversizedImages(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/heavy.png":
+			w.Write(make([]byte, imagecache.MaxDownloadBytes+1))
+		case "/huge.png":
+			w.Write(hugePNG(t, 100000, 100000))
+		}
+	}))
+	defer ts.Close()
+	s := imagecache.New(t.TempDir(), 1, ts.Client())
+
+	for _, name := range []string{"/heavy.png", "/huge.png"} {
+		if _, err := get(t, s, ts.URL+name); !errors.Is(err, imagecache.ErrTooLarge) {
+			t.Errorf("%s : %v, attendu ErrTooLarge", name, err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"image"
+	"os"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"groupie/imagecache"
+	api "groupie/models"
+)
+
+// Taille des miniatures dans la liste et la grille
+const (
+	listThumbSize    = 48
+	galleryThumbSize = 160
+)
+
+// Client HTTP des photos, séparé pour pouvoir y brancher une cassette
+var imageClient = api.NewClient(api.DefaultTimeout)
+
+// images sert les photos des artistes à toutes les vues (liste, grille, détails)
+var images = imagecache.New(imageCacheDir(), 4, imageClient)
+
+// imageCacheDir renvoie le dossier de cache des images
+func imageCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "groupie-tracker", "images")
+}
+
+// artistImage affiche la photo d'un artiste, chargée par le service d'images
+// Un placeholder est affiché pendant le chargement, une image cassée en cas d'erreur
+type artistImage struct {
+	widget.BaseWidget
+	image *canvas.Image
+	url   string // Photo affichée ou en cours de chargement
+}
+
+// newArtistImage crée une image vide de taille size affichant un placeholder
+func newArtistImage(size float32) *artistImage {
+	img := canvas.NewImageFromResource(theme.MediaPhotoIcon())
+	img.FillMode = canvas.ImageFillContain
+	img.SetMinSize(fyne.NewSize(size, size))
+	a := &artistImage{image: img}
+	a.ExtendBaseWidget(a)
+	return a
+}
+
+func (a *artistImage) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(a.image)
+}
+
+// Load affiche la photo de url, réduite à size (imagecache.Original pour la taille réelle)
+// Les chargements devenus obsolètes (cellules recyclées de la liste ou de la grille) sont ignorés
+func (a *artistImage) Load(url string, size int) {
+	if a.url == url && (a.image.Image != nil || url == "") {
+		return // Photo déjà affichée ; une erreur est retentée
+	}
+	a.url = url
+	a.show(nil, theme.MediaPhotoIcon())
+	if url == "" {
+		return
+	}
+	images.Get(url, size, func(decoded image.Image, err error) {
+		runOnUI(func() {
+			if a.url != url {
+				return // L'image affiche déjà un autre artiste
+			}
+			if err != nil {
+				a.show(nil, theme.BrokenImageIcon())
+			} else {
+				a.show(decoded, nil)
+			}
+		})
+	})
+}
+
+// show affiche une image décodée ou une icône
+func (a *artistImage) show(img image.Image, icon fyne.Resource) {
+	a.image.Image, a.image.Resource = img, icon
+	a.image.Refresh()
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+
+	"groupie/imagecache"
+)
+
+func TestArtistImageShowsError(t *testing.T) {
+	test.NewTempApp(t)
+	ts := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(ts.Close)
+	previous := images
+	images = imagecache.New(t.TempDir(), 1, ts.Client())
+	t.Cleanup(func() { images = previous })
+
+	img := newArtistImage(listThumbSize)
+	img.Load(ts.URL+"/absente.png", listThumbSize)
+	if img.image.Resource != theme.MediaPhotoIcon() {
+		t.Errorf("pendant le chargement : %v, attendu le placeholder", img.image.Resource)
+	}
+	waitFor(t, "image en erreur", func() bool { return img.image.Resource == theme.BrokenImageIcon() })
+}
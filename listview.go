@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	api "groupie/models"
+)
+
+// ListView affiche les résultats de la recherche, en liste ou en grille
+// Chaque ligne a une case de sélection, une miniature, le nom et l'étoile des favoris
+type ListView struct {
+	// Événements, à renseigner par l'application
+	OnOpen     func(api.Artist)           // Ligne ou card cliquée
+	OnCheck    func(id int, checked bool) // Case de sélection cochée ou décochée
+	OnFavorite func(id int)               // Étoile cliquée
+
+	favs      *favorites
+	isChecked func(id int) bool
+
+	artists  []api.Artist
+	concerts map[int][]api.Concert // Concerts de la période, affichés sous le nom
+	selected int                   // Ligne sélectionnée, conservée au retour sur la liste
+
+	list       *widget.List
+	gallery    *widget.GridWrap
+	viewToggle *widget.Button
+	content    fyne.CanvasObject
+}
+
+// newListView crée la vue des résultats, qui suit results
+// isChecked indique si la case d'un artiste est cochée
+func newListView(results binding.Item[Results], favs *favorites, isChecked func(id int) bool) *ListView {
+	v := &ListView{favs: favs, isChecked: isChecked, selected: -1}
+
+	v.list = widget.NewList(
+		func() int { return len(v.artists) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("Nom")
+			label.TextStyle = fyne.TextStyle{Bold: false}
+			concertsLabel := widget.NewLabel("")
+			concertsLabel.TextStyle = fyne.TextStyle{Italic: true}
+			concertsLabel.Wrapping = fyne.TextWrapWord
+			concertsLabel.Hide()
+			selectCheck := widget.NewCheck("", nil)
+			thumb := newArtistImage(listThumbSize)
+			star := widget.NewButtonWithIcon("", starBorderIcon, nil)
+			star.Importance = widget.LowImportance
+			return container.NewPadded(container.NewBorder(nil, nil, container.NewHBox(selectCheck, thumb), star, container.NewVBox(label, concertsLabel)))
+		},
+		v.updateRow,
+	)
+	v.list.OnUnselected = func(widget.ListItemID) { v.selected = -1 }
+	v.list.OnSelected = func(id widget.ListItemID) {
+		v.selected = id
+		if id < len(v.artists) && v.OnOpen != nil {
+			v.OnOpen(v.artists[id])
+		}
+	}
+
+	// Vue grille : photo, nom et année de création
+	v.gallery = newGallery(func() []api.Artist { return v.artists }, func(a api.Artist) {
+		if v.OnOpen != nil {
+			v.OnOpen(a)
+		}
+	})
+	v.gallery.Hide()
+
+	// Bascule entre la liste et la grille
+	v.viewToggle = widget.NewButtonWithIcon(lang.L("Grille"), theme.GridIcon(), v.toggleGrid)
+
+	v.content = container.NewStack(v.list, v.gallery)
+
+	results.AddListener(binding.NewDataListener(func() {
+		r, _ := results.Get()
+		v.SetResults(r.Artists, r.Concerts)
+	}))
+	return v
+}
+
+// updateRow remplit une ligne de la liste
+func (v *ListView) updateRow(i widget.ListItemID, o fyne.CanvasObject) {
+	artist := v.artists[i]
+	containerObj := o.(*fyne.Container)
+	row := containerObj.Objects[0].(*fyne.Container)
+	rows := row.Objects[0].(*fyne.Container)
+	label := rows.Objects[0].(*widget.Label)
+	label.SetText(artist.Name)
+
+	// Case de sélection (comparaison, export), puis miniature servie par le cache d'images
+	left := row.Objects[1].(*fyne.Container)
+	selectCheck := left.Objects[0].(*widget.Check)
+	selectCheck.OnChanged = nil
+	selectCheck.SetChecked(v.isChecked(artist.ID))
+	selectCheck.OnChanged = func(checked bool) {
+		if v.OnCheck != nil {
+			v.OnCheck(artist.ID, checked)
+		}
+	}
+	left.Objects[1].(*artistImage).Load(artist.Image, listThumbSize)
+
+	// Étoile des favoris
+	star := row.Objects[2].(*widget.Button)
+	star.SetIcon(v.favs.Icon(artist.ID))
+	star.OnTapped = func() {
+		if v.OnFavorite != nil {
+			v.OnFavorite(artist.ID)
+		}
+	}
+
+	// Concerts correspondant à la période, affichés sous le nom
+	concertsLabel := rows.Objects[1].(*widget.Label)
+	if matches := v.concerts[artist.ID]; len(matches) > 0 {
+		concertsLabel.SetText(formatConcerts(matches))
+		concertsLabel.Show()
+	} else {
+		concertsLabel.Hide()
+	}
+	v.list.SetItemHeight(i, o.MinSize().Height)
+}
+
+// SetResults affiche de nouveaux résultats et les concerts de la période par artiste
+// Les indices de la liste changent : l'ancienne sélection n'est plus valable
+func (v *ListView) SetResults(artists []api.Artist, concerts map[int][]api.Concert) {
+	v.artists = artists
+	v.concerts = concerts
+	v.list.Refresh()
+	v.gallery.Refresh()
+	v.list.UnselectAll()
+}
+
+// Selected renvoie l'artiste de la ligne sélectionnée
+func (v *ListView) Selected() (api.Artist, bool) {
+	if v.selected < 0 || v.selected >= len(v.artists) {
+		return api.Artist{}, false
+	}
+	return v.artists[v.selected], true
+}
+
+// toggleGrid bascule entre la liste et la grille
+func (v *ListView) toggleGrid() {
+	if v.gallery.Visible() {
+		v.gallery.Hide()
+		v.list.Show()
+		v.viewToggle.SetText(lang.L("Grille"))
+		v.viewToggle.SetIcon(theme.GridIcon())
+	} else {
+		v.list.Hide()
+		v.gallery.Show()
+		v.viewToggle.SetText(lang.L("Liste"))
+		v.viewToggle.SetIcon(theme.ListIcon())
+	}
+}
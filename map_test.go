@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+
+	"groupie/cassette"
+	"groupie/geo"
+)
+
+// useCassette rejoue une cassette de testdata sur les clients du géocodeur et des tuiles
+func useCassette(t *testing.T, name string) {
+	t.Helper()
+	rec, err := cassette.New("testdata/"+name, cassette.Replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	geo.SetTransport(rec)
+	client.SetTransport(rec)
+	t.Cleanup(func() {
+		geo.SetTransport(nil)
+		client.SetTransport(nil)
+	})
+}
+
+func TestGetOSMTileURL(t *testing.T) {
+	got := GetOSMTileURL(48.8534951, 2.3483915, 4)
+	if want := "https://tile.openstreetmap.org/4/8/5.png"; got != want {
+		t.Errorf("GetOSMTileURL = %q, attendu %q", got, want)
+	}
+}
+
+func TestSettingsChangeTileProvider(t *testing.T) {
+	setTileProvider("OpenTopoMap")
+	t.Cleanup(func() { setTileProvider(tileProviders[0].Name) })
+
+	if got, want := GetOSMTileURL(48.8534951, 2.3483915, 4), "https://a.tile.opentopomap.org/4/8/5.png"; got != want {
+		t.Errorf("GetOSMTileURL = %q, attendu %q", got, want)
+	}
+}
+
+func TestFetchMapTile(t *testing.T) {
+	useCassette(t, "tile.json")
+
+	// Paris est géocodé puis la tuile 4/8/5 téléchargée et décodée, sans réseau
+	img, err := fetchMapTile("paris, france")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := img.Bounds().Size(); size.X != 8 || size.Y != 8 {
+		t.Errorf("tuile de %v, attendu 8x8", size)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	_ "image/png" // Tuiles OpenStreetMap
+	"net/http"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+
+	"groupie/geo"
+	api "groupie/models"
+)
+
+// MapView affiche une carte avec les lieux de concerts d'un artiste
+type MapView struct {
+	// Événements, à renseigner par l'application
+	OnBack func()
+
+	artist  api.Artist
+	content fyne.CanvasObject
+}
+
+// newMapView crée la carte des lieux de concerts de l'artiste
+// La carte est centrée sur focus s'il est renseigné, sinon sur le premier lieu correspondant
+// au filtre de lieu place (vide : aucun filtre)
+func newMapView(artist api.Artist, focus, place string) *MapView {
+	v := &MapView{artist: artist}
+
+	title := widget.NewLabelWithStyle(fmt.Sprintf(lang.L("Lieux de concerts de %s"), artist.Name), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	backBtn := widget.NewButton(lang.L("Retour (Échap)"), func() {
+		if v.OnBack != nil {
+			v.OnBack()
+		}
+	})
+	header := container.NewBorder(nil, nil, backBtn, nil, title)
+
+	// Lieux chargés en arrière-plan, puis la carte et les coordonnées de chaque lieu
+	locTask := newTask(func() ([]string, error) { return api.FetchLocationList(artist.LocationsURL) })
+	body := newTaskView(locTask, lang.L("Chargement des lieux..."), func(locations []string) fyne.CanvasObject {
+		return newMapBody(locations, focus, place)
+	})
+
+	v.content = container.NewBorder(header, nil, nil, nil, body)
+	return v
+}
+
+// placeName nettoie un lieu de l'API pour le géocodeur : "North Carolina-Usa" devient "North Carolina, Usa"
+func placeName(loc string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(strings.TrimSpace(loc), "_", " "), "-", ", ")
+}
+
+// newMapBody crée la carte, centrée sur focus ou sur le premier lieu, et la liste des lieux
+// Les lieux correspondant au filtre place sont en gras
+func newMapBody(locations []string, focus, place string) fyne.CanvasObject {
+	if len(locations) == 0 {
+		return widget.NewLabel(lang.L("Aucun lieu de concert disponible"))
+	}
+
+	place = strings.ToLower(strings.TrimSpace(place))
+	filtered := ""
+
+	// Créer une liste des lieux avec leurs coordonnées
+	locationsList := container.NewVBox()
+	for _, loc := range locations {
+		cityName := placeName(loc)
+		if cityName == "" {
+			continue
+		}
+		label := cityName
+		if place != "" && strings.Contains(strings.ToLower(loc+" "+cityName), place) {
+			label = "**" + cityName + "**"
+			if filtered == "" {
+				filtered = cityName
+			}
+		}
+		locationLabel := widget.NewRichTextFromMarkdown(label)
+		locationsList.Add(createCard(locationLabel))
+
+		// Coordonnées ajoutées au nom une fois le lieu géocodé
+		coords := newTask(func() ([2]string, error) {
+			lat, lon, err := geo.Lookup(cityName)
+			return [2]string{lat, lon}, err
+		})
+		coords.OnChange(func(t *Task[[2]string]) {
+			if t.State() == TaskDone {
+				locationLabel.ParseMarkdown(label + fmt.Sprintf(" (%.4s, %.4s)", t.Value()[0], t.Value()[1]))
+			}
+		})
+	}
+
+	// Prendre le lieu demandé, le premier lieu filtré ou le premier lieu, pour afficher une carte centrée
+	if focus == "" {
+		focus = filtered
+	}
+	if focus == "" {
+		focus = placeName(locations[0])
+	}
+	tile := newTask(func() (image.Image, error) { return fetchMapTile(focus) })
+	mapHolder := newTaskView(tile, lang.L("Chargement de la carte..."), func(img image.Image) fyne.CanvasObject {
+		mapImage := canvas.NewImageFromImage(img)
+		mapImage.FillMode = canvas.ImageFillContain
+		mapImage.SetMinSize(fyne.NewSize(600, 400))
+		return mapImage
+	})
+
+	// Fond aux couleurs du thème autour de la tuile
+	mapPane := container.NewStack(newThemedRect(colorNameMapBackground, ""), mapHolder)
+	return container.NewHSplit(mapPane, container.NewVScroll(locationsList))
+}
+
+// fetchMapTile géocode un lieu puis télécharge et décode la tuile OpenStreetMap centrée dessus
+func fetchMapTile(place string) (image.Image, error) {
+	lat, lon, err := geo.Lookup(place)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convertir lat/lon en float
+	latF, _ := strconv.ParseFloat(lat, 64)
+	lonF, _ := strconv.ParseFloat(lon, 64)
+
+	// Récupérer la tuile de carte
+	zoom := 4
+	resp, err := client.Get(GetOSMTileURL(latF, lonF, zoom))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(lang.L("tuile indisponible : %s"), resp.Status)
+	}
+	img, _, err := image.Decode(resp.Body)
+	return img, err
+}
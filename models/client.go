@@ -2,33 +2,157 @@ package groupie
 
 import (
 	"encoding/json" // Pour décoder les réponses JSON de l'API
-	"errors"        // Pour gérer les erreurs personnalisées
+	"errors"        // Pour reconnaître les erreurs de l'API
 	"fmt"           // Pour formater les chaînes de caractères
 	"io"            // Pour lire le corps des réponses HTTP
 	"net/http"      // Pour effectuer les requêtes HTTP
 	"strings"       // Pour manipuler les chaînes (nettoyage, formatage)
+	"sync"          // Pour modifier le client pendant les requêtes
+	"sync/atomic"   // Pour lire l'URL et le client sans verrou
 	"time"          // Pour gérer les délais de requêtes
 )
 
-// URL de base de l'API Groupie Tracker
-const baseURL = "https://groupietrackers.herokuapp.com/api"
+// URL de base par défaut de l'API Groupie Tracker
+const DefaultBaseURL = "https://groupietrackers.herokuapp.com/api"
 
-// Client HTTP avec timeout de 10 secondes pour éviter les blocages
-var httpClient = &http.Client{
-	Timeout: 10 * time.Second,
+// URL de base utilisée par les requêtes, modifiable avec SetBaseURL pendant les requêtes
+var baseURL atomic.Pointer[string]
+
+// SetBaseURL change l'URL de base de l'API, ex: un faux serveur local (voir le paquet fakeapi)
+func SetBaseURL(url string) {
+	url = strings.TrimSuffix(url, "/")
+	baseURL.Store(&url)
+}
+
+// BaseURL renvoie l'URL de base utilisée par les requêtes
+func BaseURL() string {
+	if url := baseURL.Load(); url != nil {
+		return *url
+	}
+	return DefaultBaseURL
+}
+
+// apiURL renvoie l'URL d'un endpoint de l'API, ex: apiURL("/artists")
+func apiURL(path string) string {
+	return BaseURL() + path
+}
+
+// DefaultTimeout est le délai maximal par défaut d'une requête
+const DefaultTimeout = 10 * time.Second
+
+// Client est un client HTTP modifiable pendant que des requêtes sont en cours
+// Chaque modification remplace le client : les requêtes en cours gardent l'ancien
+type Client struct {
+	mu      sync.Mutex // Sérialise les modifications
+	current atomic.Pointer[http.Client]
+}
+
+// NewClient crée un client HTTP avec un délai maximal par requête
+func NewClient(timeout time.Duration) *Client {
+	c := &Client{}
+	c.current.Store(&http.Client{Timeout: timeout})
+	return c
+}
+
+// Do envoie une requête avec le client courant
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	return c.current.Load().Do(req)
+}
+
+// Get envoie une requête GET avec le client courant
+func (c *Client) Get(url string) (*http.Response, error) {
+	return c.current.Load().Get(url)
+}
+
+// SetTimeout change le délai maximal des requêtes suivantes
+func (c *Client) SetTimeout(d time.Duration) {
+	c.update(func(hc *http.Client) { hc.Timeout = d })
+}
+
+// SetTransport change le transport des requêtes suivantes, ex: une cassette (nil : transport par défaut)
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.update(func(hc *http.Client) { hc.Transport = rt })
+}
+
+// update remplace le client courant par une copie modifiée
+func (c *Client) update(change func(*http.Client)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	next := *c.current.Load()
+	change(&next)
+	c.current.Store(&next)
+}
+
+// Client HTTP de l'API avec timeout pour éviter les blocages
+var httpClient = NewClient(DefaultTimeout)
+
+// SetTimeout change le délai maximal des requêtes suivantes
+func SetTimeout(d time.Duration) {
+	httpClient.SetTimeout(d)
+}
+
+// SetTransport change le transport des requêtes, ex: une cassette (voir le paquet cassette)
+func SetTransport(rt http.RoundTripper) {
+	httpClient.SetTransport(rt)
+}
+
+// StatusError est une réponse en erreur de l'API, ex: "erreur API : 404 Not Found"
+// Les messages du paquet restent en français ; l'interface graphique les traduit
+type StatusError struct {
+	Status string // Statut HTTP, ex: "404 Not Found"
+}
+
+func (e *StatusError) Error() string {
+	return "erreur API : " + e.Status
+}
+
+// AsStatusError renvoie l'erreur de l'API contenue dans err, s'il y en a une
+func AsStatusError(err error) (*StatusError, bool) {
+	var se *StatusError
+	ok := errors.As(err, &se)
+	return se, ok
+}
+
+// Parties du catalogue téléchargé par FetchCatalog
+const (
+	CatalogArtists  = "artists"
+	CatalogConcerts = "concerts"
+)
+
+// CatalogError indique quelle partie du catalogue n'a pas pu être téléchargée
+// Le message reste en français ; l'interface le traduit d'après Part
+type CatalogError struct {
+	Part string // CatalogArtists ou CatalogConcerts
+	Err  error
+}
+
+func (e *CatalogError) Error() string {
+	if e.Part == CatalogConcerts {
+		return "concerts indisponibles : " + e.Err.Error()
+	}
+	return "artistes indisponibles : " + e.Err.Error()
+}
+
+func (e *CatalogError) Unwrap() error {
+	return e.Err
+}
+
+// statusError décrit une réponse en erreur de l'API
+func statusError(resp *http.Response) error {
+	return &StatusError{Status: resp.Status}
 }
 
 // FetchArtists récupère la liste des artistes depuis l'API
 // Elle renvoie un tableau d'objets Artist ou une erreur
 func FetchArtists() ([]Artist, error) {
-	resp, err := httpClient.Get(baseURL + "/artists")
+	resp, err := httpClient.Get(apiURL("/artists"))
 	if err != nil {
 		return nil, err // Erreur réseau ou requête
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, errors.New("API error: " + resp.Status) // Erreur côté serveur
+		return nil, statusError(resp) // Erreur côté serveur
 	}
 
 	var artists []Artist
@@ -45,17 +169,17 @@ func FetchArtists() ([]Artist, error) {
 func GetFromURL(url string) string {
 	resp, err := httpClient.Get(url)
 	if err != nil {
-		return "Erreur de chargement: " + err.Error()
+		return fmt.Sprintf("Erreur de chargement : %s", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return "Erreur serveur: " + resp.Status
+		return fmt.Sprintf("Erreur serveur : %s", resp.Status)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "Erreur lecture: " + err.Error()
+		return fmt.Sprintf("Erreur de lecture : %s", err)
 	}
 
 	return string(body) // Retourne le JSON brut sous forme de string
@@ -64,44 +188,72 @@ func GetFromURL(url string) string {
 // FetchLocation récupère les lieux de concert et les formate
 // Exemple : "new_york" devient "New York"
 func FetchLocation(url string) string {
+	locations, err := FetchLocationList(url)
+	if err != nil {
+		return fmt.Sprintf("Erreur : %s", err)
+	}
+	return strings.Join(locations, "\n") // Retourne une liste formatée
+}
+
+// FetchDates récupère les dates de concert et les nettoie
+// Supprime les caractères parasites comme "*"
+func FetchDates(url string) string {
+	dates, err := FetchDateList(url)
+	if err != nil {
+		return fmt.Sprintf("Erreur : %s", err)
+	}
+	return strings.Join(dates, "\n") // Liste propre des dates
+}
+
+// FetchLocationList récupère les lieux de concert formatés comme FetchLocation
+// Les erreurs sont renvoyées au lieu d'être écrites dans le texte
+func FetchLocationList(url string) ([]string, error) {
 	resp, err := httpClient.Get(url)
 	if err != nil {
-		return "Erreur: " + err.Error()
+		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != 200 {
+		return nil, statusError(resp)
+	}
+
 	var loc LocationData
 	if err := json.NewDecoder(resp.Body).Decode(&loc); err != nil {
-		return "Erreur lecture données"
+		return nil, err
 	}
 
 	formatted := []string{}
 	for _, l := range loc.Locations {
 		// Nettoyage : remplace "_" par " ", met la première lettre en majuscule
-		formatted = append(formatted, strings.Title(strings.ReplaceAll(l, "_", " ")))
+		formatted = append(formatted, titleCase(strings.ReplaceAll(l, "_", " ")))
 	}
-	return strings.Join(formatted, "\n") // Retourne une liste formatée
+	return formatted, nil
 }
 
-// FetchDates récupère les dates de concert et les nettoie
-// Supprime les caractères parasites comme "*"
-func FetchDates(url string) string {
+// FetchDateList récupère les dates de concert nettoyées comme FetchDates
+// Les erreurs sont renvoyées au lieu d'être écrites dans le texte
+func FetchDateList(url string) ([]string, error) {
 	resp, err := httpClient.Get(url)
 	if err != nil {
-		return "Erreur: " + err.Error()
+		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != 200 {
+		return nil, statusError(resp)
+	}
+
 	var d DateData
 	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
-		return "Erreur lecture données"
+		return nil, err
 	}
 
 	formatted := []string{}
 	for _, date := range d.Dates {
 		formatted = append(formatted, strings.ReplaceAll(date, "*", ""))
 	}
-	return strings.Join(formatted, "\n") // Liste propre des dates
+	return formatted, nil
 }
 
 // FetchRelations récupère les relations entre lieux et dates
@@ -109,19 +261,19 @@ func FetchDates(url string) string {
 func FetchRelations(url string) string {
 	resp, err := httpClient.Get(url)
 	if err != nil {
-		return "Erreur: " + err.Error()
+		return fmt.Sprintf("Erreur : %s", err)
 	}
 	defer resp.Body.Close()
 
 	var rel RelationData
 	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
-		return "Erreur lecture données"
+		return "Erreur de lecture des données"
 	}
 
 	var builder strings.Builder
 	for loc, dates := range rel.DatesLocations {
 		// Nettoyage du nom du lieu
-		cleanLoc := strings.Title(strings.ReplaceAll(loc, "_", " "))
+		cleanLoc := titleCase(strings.ReplaceAll(loc, "_", " "))
 		builder.WriteString(fmt.Sprintf("%s :\n", cleanLoc))
 		for _, d := range dates {
 			builder.WriteString(fmt.Sprintf("  - %s\n", d)) // Ajout des dates
@@ -142,7 +294,7 @@ func FetchImage(url string) (string, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return "", errors.New("API error: " + resp.Status)
+		return "", statusError(resp)
 	}
 
 	var artist Artist
@@ -163,7 +315,7 @@ func FetchFirstAlbum(url string) (string, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return "", errors.New("API error: " + resp.Status)
+		return "", statusError(resp)
 	}
 
 	var artist Artist
@@ -184,7 +336,7 @@ func FetchMembers(url string) ([]string, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, errors.New("API error: " + resp.Status)
+		return nil, statusError(resp)
 	}
 
 	var artist Artist
@@ -205,7 +357,7 @@ func FetchCreationDate(url string) (int, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return 0, errors.New("API error: " + resp.Status)
+		return 0, statusError(resp)
 	}
 
 	var artist Artist
@@ -216,3 +368,60 @@ func FetchCreationDate(url string) (int, error) {
 
 	return artist.CreationDate, nil
 }
+
+// FetchRelationIndex récupère les relations lieu/date de tous les artistes
+// en une seule requête sur l'endpoint /relation
+func FetchRelationIndex() ([]RelationData, error) {
+	resp, err := httpClient.Get(apiURL("/relation"))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, statusError(resp)
+	}
+
+	var index RelationIndex
+	err = json.NewDecoder(resp.Body).Decode(&index)
+	if err != nil {
+		return nil, err
+	}
+
+	return index.Index, nil
+}
+
+// FetchRelation récupère les relations lieu/date d'un artiste
+// Contrairement à FetchRelations, les données sont renvoyées brutes pour être analysées
+func FetchRelation(url string) (RelationData, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return RelationData{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return RelationData{}, statusError(resp)
+	}
+
+	var rel RelationData
+	err = json.NewDecoder(resp.Body).Decode(&rel)
+	if err != nil {
+		return RelationData{}, err
+	}
+
+	return rel, nil
+}
+
+// FetchCatalog télécharge les artistes et l'index de leurs concerts
+func FetchCatalog() ([]Artist, map[int][]Concert, error) {
+	artists, err := FetchArtists()
+	if err != nil {
+		return nil, nil, &CatalogError{Part: CatalogArtists, Err: err}
+	}
+	relations, err := FetchRelationIndex()
+	if err != nil {
+		return nil, nil, &CatalogError{Part: CatalogConcerts, Err: err}
+	}
+	return artists, BuildConcertIndex(relations), nil
+}
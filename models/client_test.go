@@ -0,0 +1,148 @@
+package groupie_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"groupie/fakeapi"
+	api "groupie/models"
+)
+
+// useFakeAPI fait pointer le client sur un faux serveur le temps du test
+func useFakeAPI(t *testing.T, opts fakeapi.Options) *fakeapi.Server {
+	t.Helper()
+	fake := fakeapi.New(opts)
+	ts := httptest.NewServer(fake)
+	api.SetBaseURL(ts.URL + "/api")
+	t.Cleanup(func() {
+		ts.Close()
+		api.SetBaseURL(api.DefaultBaseURL)
+	})
+	return fake
+}
+
+func TestFetchArtists(t *testing.T) {
+	useFakeAPI(t, fakeapi.Options{})
+
+	artists, err := api.FetchArtists()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(artists) == 0 {
+		t.Fatal("aucun artiste")
+	}
+	queen := artists[0]
+	if queen.Name != "Queen" || queen.CreationDate != 1970 || len(queen.Members) == 0 {
+		t.Errorf("premier artiste inattendu : %+v", queen)
+	}
+}
+
+func TestFetchRelationIndex(t *testing.T) {
+	useFakeAPI(t, fakeapi.Options{})
+
+	relations, err := api.FetchRelationIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	concerts := api.BuildConcertIndex(relations)
+	if len(concerts[1]) == 0 {
+		t.Fatal("aucun concert pour l'artiste 1")
+	}
+	for _, c := range concerts[1] {
+		if c.ArtistID != 1 || c.Date.IsZero() || c.City == "" {
+			t.Errorf("concert mal analysé : %+v", c)
+		}
+	}
+}
+
+func TestFetchRelationFromArtistURL(t *testing.T) {
+	useFakeAPI(t, fakeapi.Options{})
+
+	artists, err := api.FetchArtists()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel, err := api.FetchRelation(artists[0].RelationsURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.ID != artists[0].ID || len(rel.DatesLocations) == 0 {
+		t.Errorf("relation inattendue : %+v", rel)
+	}
+}
+
+func TestFetchLocationAndDateLists(t *testing.T) {
+	useFakeAPI(t, fakeapi.Options{})
+
+	artists, err := api.FetchArtists()
+	if err != nil {
+		t.Fatal(err)
+	}
+	locations, err := api.FetchLocationList(artists[0].LocationsURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locations) == 0 || locations[0] != "North Carolina-Usa" {
+		t.Errorf("lieux = %q", locations)
+	}
+	if _, err := api.FetchLocationList(strings.TrimSuffix(artists[0].LocationsURL, "1") + "999"); err == nil {
+		t.Error("artiste inconnu : erreur attendue")
+	}
+	dates, err := api.FetchDateList(artists[0].ConcertDates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range dates {
+		if strings.Contains(d, "*") {
+			t.Errorf("date non nettoyée : %q", d)
+		}
+	}
+}
+
+func TestFetchErrors(t *testing.T) {
+	for name, opts := range map[string]fakeapi.Options{
+		"erreur 500":    {Fail: 1},
+		"corps tronqué": {Truncate: 1},
+		"JSON invalide": {Malformed: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			useFakeAPI(t, opts)
+			if _, err := api.FetchArtists(); err == nil {
+				t.Error("FetchArtists : erreur attendue")
+			}
+			if _, err := api.FetchRelationIndex(); err == nil {
+				t.Error("FetchRelationIndex : erreur attendue")
+			}
+		})
+	}
+}
+
+func TestSettersDuringRequests(t *testing.T) {
+	fake := httptest.NewServer(fakeapi.New(fakeapi.Options{}))
+	t.Cleanup(fake.Close)
+	api.SetBaseURL(fake.URL + "/api")
+	t.Cleanup(func() {
+		api.SetBaseURL(api.DefaultBaseURL)
+		api.SetTimeout(api.DefaultTimeout)
+	})
+
+	// Les paramètres changent pendant que des requêtes sont en cours (vérifié par go test -race)
+	done := make(chan error)
+	for i := 0; i < 4; i++ {
+		go func() {
+			_, err := api.FetchArtists()
+			done <- err
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		api.SetTimeout(time.Duration(5+i) * time.Second)
+		api.SetBaseURL(fake.URL + "/api/")
+	}
+	for i := 0; i < 4; i++ {
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	}
+}
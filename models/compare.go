@@ -0,0 +1,156 @@
+package groupie
+
+import (
+	"sort"
+	"time"
+)
+
+// ArtistSummary regroupe les informations d'un artiste utilisées par la comparaison
+type ArtistSummary struct {
+	Artist
+	ConcertCount int      // Nombre de concerts
+	Cities       []string // Lieux visités, triés (ex: "Paris, France")
+	Countries    []string // Pays visités, triés
+}
+
+// SharedCity est un lieu où plusieurs des artistes comparés ont joué
+type SharedCity struct {
+	Place     string // Lieu lisible (ex: "Paris, France")
+	ArtistIDs []int  // Artistes y ayant joué
+}
+
+// OverlapDays est l'écart maximal, en jours, entre deux concerts de tournées simultanées
+const OverlapDays = 7
+
+// TourOverlap est une période pendant laquelle deux artistes ont donné des concerts
+// à moins de OverlapDays jours d'écart
+type TourOverlap struct {
+	ArtistA, ArtistB int       // IDs des deux artistes
+	From, To         time.Time // Premier et dernier de ces concerts
+	Cities           []string  // Lieux où les deux artistes ont joué à moins de OverlapDays jours d'écart, triés
+}
+
+// Comparison est le résultat de la comparaison de plusieurs artistes
+type Comparison struct {
+	Artists      []ArtistSummary
+	SharedCities []SharedCity
+	Overlaps     []TourOverlap
+}
+
+// Compare compare les artistes à partir de leurs concerts
+func Compare(artists []Artist, concerts map[int][]Concert) Comparison {
+	var cmp Comparison
+	cityArtists := map[string][]int{}
+
+	for _, a := range artists {
+		summary := ArtistSummary{Artist: a, ConcertCount: len(concerts[a.ID])}
+		cities := map[string]bool{}
+		countries := map[string]bool{}
+		for _, c := range concerts[a.ID] {
+			cities[c.Place()] = true
+			if c.Country != "" {
+				countries[c.Country] = true
+			}
+		}
+		for city := range cities {
+			summary.Cities = append(summary.Cities, city)
+			cityArtists[city] = append(cityArtists[city], a.ID)
+		}
+		for country := range countries {
+			summary.Countries = append(summary.Countries, country)
+		}
+		sort.Strings(summary.Cities)
+		sort.Strings(summary.Countries)
+		cmp.Artists = append(cmp.Artists, summary)
+	}
+
+	// Lieux communs à au moins deux artistes
+	for city, ids := range cityArtists {
+		if len(ids) >= 2 {
+			cmp.SharedCities = append(cmp.SharedCities, SharedCity{Place: city, ArtistIDs: ids})
+		}
+	}
+	sort.Slice(cmp.SharedCities, func(i, j int) bool {
+		return cmp.SharedCities[i].Place < cmp.SharedCities[j].Place
+	})
+
+	// Périodes de tournée communes, pour chaque paire d'artistes
+	for i := 0; i < len(artists); i++ {
+		for j := i + 1; j < len(artists); j++ {
+			cmp.Overlaps = append(cmp.Overlaps, tourOverlaps(artists[i].ID, artists[j].ID, concerts)...)
+		}
+	}
+	return cmp
+}
+
+// IsShared indique si un lieu fait partie des lieux communs
+func (c Comparison) IsShared(place string) bool {
+	for _, s := range c.SharedCities {
+		if s.Place == place {
+			return true
+		}
+	}
+	return false
+}
+
+// tourOverlaps trouve les périodes où deux artistes ont joué à moins de OverlapDays jours d'écart
+// Chaque paire de concerts proches donne un intervalle ; les intervalles proches sont fusionnés
+func tourOverlaps(idA, idB int, concerts map[int][]Concert) []TourOverlap {
+	const window = OverlapDays * 24 * time.Hour
+	var periods []TourOverlap
+	for _, a := range concerts[idA] {
+		for _, b := range concerts[idB] {
+			if a.Date.Sub(b.Date).Abs() > window {
+				continue
+			}
+			p := TourOverlap{ArtistA: idA, ArtistB: idB, From: earlier(a.Date, b.Date), To: later(a.Date, b.Date)}
+			if a.Place() == b.Place() {
+				p.Cities = []string{a.Place()}
+			}
+			periods = append(periods, p)
+		}
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i].From.Before(periods[j].From) })
+
+	var merged []TourOverlap
+	for _, p := range periods {
+		if n := len(merged); n > 0 && p.From.Sub(merged[n-1].To) <= window {
+			last := &merged[n-1]
+			last.To = later(last.To, p.To)
+			for _, city := range p.Cities {
+				if !containsString(last.Cities, city) {
+					last.Cities = append(last.Cities, city)
+				}
+			}
+			continue
+		}
+		merged = append(merged, p)
+	}
+	for _, p := range merged {
+		sort.Strings(p.Cities)
+	}
+	return merged
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
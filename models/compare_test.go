@@ -0,0 +1,57 @@
+package groupie_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	api "groupie/models"
+)
+
+// concert crée un concert, date au format de l'API
+func concert(id int, date, city, country string) api.Concert {
+	d, err := api.ParseConcertDate(date)
+	if err != nil {
+		panic(err)
+	}
+	return api.Concert{ArtistID: id, Date: d, City: city, Country: country}
+}
+
+func TestCompareNoOverlapBetweenTours(t *testing.T) {
+	// Les tournées se chevauchent d'un premier à un dernier concert, mais jamais à moins d'une semaine
+	artists := []api.Artist{{ID: 1, Name: "Queen"}, {ID: 2, Name: "SOJA"}}
+	concerts := map[int][]api.Concert{
+		1: {concert(1, "01-01-2019", "Paris", "France"), concert(1, "01-12-2019", "Lyon", "France")},
+		2: {concert(2, "01-06-2019", "Paris", "France"), concert(2, "20-06-2019", "Berlin", "Germany")},
+	}
+	if got := api.Compare(artists, concerts).Overlaps; len(got) != 0 {
+		t.Errorf("Overlaps = %+v, attendu aucune période commune", got)
+	}
+}
+
+func TestCompareOverlaps(t *testing.T) {
+	artists := []api.Artist{{ID: 1, Name: "Queen"}, {ID: 2, Name: "SOJA"}}
+	concerts := map[int][]api.Concert{
+		1: {
+			concert(1, "01-03-2019", "Paris", "France"),
+			concert(1, "08-03-2019", "Berlin", "Germany"),
+			concert(1, "01-10-2019", "Tokyo", "Japan"),
+		},
+		2: {
+			concert(2, "03-03-2019", "Paris", "France"),
+			concert(2, "12-03-2019", "Munich", "Germany"),
+			concert(2, "05-10-2019", "Osaka", "Japan"),
+		},
+	}
+	day := func(s string) time.Time {
+		d, _ := api.ParseConcertDate(s)
+		return d
+	}
+	want := []api.TourOverlap{
+		{ArtistA: 1, ArtistB: 2, From: day("01-03-2019"), To: day("12-03-2019"), Cities: []string{"Paris, France"}},
+		{ArtistA: 1, ArtistB: 2, From: day("01-10-2019"), To: day("05-10-2019")},
+	}
+	if got := api.Compare(artists, concerts).Overlaps; !reflect.DeepEqual(got, want) {
+		t.Errorf("Overlaps = %+v\nattendu %+v", got, want)
+	}
+}
@@ -0,0 +1,253 @@
+package groupie
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DateLayout est le format des dates renvoyées par l'API (jour-mois-année)
+const DateLayout = "02-01-2006"
+
+// Concert représente un concert daté et localisé d'un artiste.
+// Il est construit à partir des relations lieu/date de l'API.
+type Concert struct {
+	ArtistID int       // Identifiant de l'artiste
+	Location string    // Lieu brut de l'API (ex: "paris-france")
+	City     string    // Ville formatée (ex: "Paris")
+	Country  string    // Pays formaté (ex: "France")
+	Date     time.Time // Date du concert
+}
+
+// Place renvoie le lieu lisible du concert, ex: "Paris, France"
+func (c Concert) Place() string {
+	if c.Country == "" {
+		return c.City
+	}
+	return c.City + ", " + c.Country
+}
+
+// ParseConcertDate convertit une date de l'API en time.Time
+// Le "*" ajouté par l'endpoint /dates devant certaines dates est ignoré
+func ParseConcertDate(s string) (time.Time, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "*")
+	return time.Parse(DateLayout, s)
+}
+
+// ParseLocation sépare un lieu brut de l'API en ville et pays
+// Exemple : "north_carolina-usa" devient "North Carolina", "USA"
+func ParseLocation(raw string) (city, country string) {
+	city = raw
+	if i := strings.LastIndex(raw, "-"); i >= 0 {
+		city, country = raw[:i], raw[i+1:]
+	}
+	return formatPlace(city), formatPlace(country)
+}
+
+// Codes de pays utilisés par l'API à la place du nom, écrits en majuscules
+var countryCodes = map[string]bool{"usa": true, "uk": true, "uae": true}
+
+// formatPlace nettoie un nom de lieu : "_" devient " " et les mots sont capitalisés
+// Les codes de pays comme "usa" ou "uk" sont mis en majuscules
+func formatPlace(s string) string {
+	s = strings.ReplaceAll(s, "_", " ")
+	if countryCodes[strings.ToLower(s)] {
+		return strings.ToUpper(s)
+	}
+	return titleCase(s)
+}
+
+// titleCase met en majuscule la première lettre de chaque mot, accents compris
+// Ex: "são paulo" devient "São Paulo", "paris-france" devient "Paris-France"
+func titleCase(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	start := true
+	for _, r := range s {
+		if start {
+			r = unicode.ToTitle(r)
+		}
+		b.WriteRune(r)
+		start = !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}
+	return b.String()
+}
+
+// ConcertsFromRelation transforme les relations d'un artiste en concerts
+// triés par date (puis par lieu pour un ordre stable)
+// Les dates illisibles sont ignorées
+func ConcertsFromRelation(rel RelationData) []Concert {
+	var concerts []Concert
+	for loc, dates := range rel.DatesLocations {
+		city, country := ParseLocation(loc)
+		for _, d := range dates {
+			date, err := ParseConcertDate(d)
+			if err != nil {
+				continue
+			}
+			concerts = append(concerts, Concert{
+				ArtistID: rel.ID,
+				Location: loc,
+				City:     city,
+				Country:  country,
+				Date:     date,
+			})
+		}
+	}
+	sortConcerts(concerts)
+	return concerts
+}
+
+// sortConcerts trie les concerts par date, puis par lieu pour un ordre stable
+func sortConcerts(concerts []Concert) {
+	sort.Slice(concerts, func(i, j int) bool {
+		if !concerts[i].Date.Equal(concerts[j].Date) {
+			return concerts[i].Date.Before(concerts[j].Date)
+		}
+		return concerts[i].Location < concerts[j].Location
+	})
+}
+
+// BuildConcertIndex regroupe les concerts de tous les artistes par ID d'artiste
+func BuildConcertIndex(relations []RelationData) map[int][]Concert {
+	index := make(map[int][]Concert, len(relations))
+	for _, rel := range relations {
+		index[rel.ID] = ConcertsFromRelation(rel)
+	}
+	return index
+}
+
+// PlayedBetween garde les concerts joués entre from et to (bornes incluses)
+// Une borne nulle n'est pas prise en compte
+// Si place n'est pas vide, seuls les concerts dont la ville ou le pays contient place sont gardés
+func PlayedBetween(concerts []Concert, from, to time.Time, place string) []Concert {
+	place = strings.ToLower(strings.TrimSpace(place))
+	var res []Concert
+	for _, c := range concerts {
+		if !from.IsZero() && c.Date.Before(from) {
+			continue
+		}
+		if !to.IsZero() && c.Date.After(to) {
+			continue
+		}
+		if place != "" && !strings.Contains(strings.ToLower(c.Place()), place) {
+			continue
+		}
+		res = append(res, c)
+	}
+	return res
+}
+
+// ConcertFilter sélectionne des concerts, tous artistes confondus
+// Les champs vides ou nuls ne filtrent pas
+type ConcertFilter struct {
+	ArtistID int       // Un seul artiste
+	City     string    // La ville contient ce texte (sans tenir compte de la casse)
+	Country  string    // Le pays contient ce texte
+	From, To time.Time // Période, bornes incluses
+}
+
+// FindConcerts renvoie les concerts de l'index qui passent le filtre
+// Le résultat est trié par date, puis par ID d'artiste
+func FindConcerts(index map[int][]Concert, f ConcertFilter) []Concert {
+	city := strings.ToLower(strings.TrimSpace(f.City))
+	country := strings.ToLower(strings.TrimSpace(f.Country))
+	var res []Concert
+	for id, concerts := range index {
+		if f.ArtistID != 0 && id != f.ArtistID {
+			continue
+		}
+		for _, c := range PlayedBetween(concerts, f.From, f.To, "") {
+			if city != "" && !strings.Contains(strings.ToLower(c.City), city) {
+				continue
+			}
+			if country != "" && !strings.Contains(strings.ToLower(c.Country), country) {
+				continue
+			}
+			res = append(res, c)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].Date.Equal(res[j].Date) {
+			return res[i].Date.Before(res[j].Date)
+		}
+		if res[i].ArtistID != res[j].ArtistID {
+			return res[i].ArtistID < res[j].ArtistID
+		}
+		return res[i].Location < res[j].Location
+	})
+	return res
+}
+
+// ParseDateBound lit une borne de période saisie par l'utilisateur
+// Formats acceptés : "2019" ou "12-05-2019"
+// Pour une borne de fin (end = true), une année seule désigne le 31 décembre
+// Une saisie vide renvoie une date nulle sans erreur
+func ParseDateBound(s string, end bool) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if year, err := strconv.Atoi(s); err == nil && len(s) == 4 {
+		if end {
+			return time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC), nil
+		}
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Parse(DateLayout, s)
+}
+
+// ConcertsInMonth renvoie les concerts d'un mois groupés par jour du mois
+// keep permet de ne garder que certains artistes (nil pour tous)
+// Les concerts d'un même jour sont triés par ID d'artiste pour un ordre stable
+func ConcertsInMonth(index map[int][]Concert, year int, month time.Month, keep func(artistID int) bool) map[int][]Concert {
+	days := map[int][]Concert{}
+	for id, concerts := range index {
+		if keep != nil && !keep(id) {
+			continue
+		}
+		for _, c := range concerts {
+			if c.Date.Year() == year && c.Date.Month() == month {
+				days[c.Date.Day()] = append(days[c.Date.Day()], c)
+			}
+		}
+	}
+	for _, concerts := range days {
+		sort.Slice(concerts, func(i, j int) bool {
+			if concerts[i].ArtistID != concerts[j].ArtistID {
+				return concerts[i].ArtistID < concerts[j].ArtistID
+			}
+			return concerts[i].Location < concerts[j].Location
+		})
+	}
+	return days
+}
+
+// ConcertYears renvoie les années où au moins un concert a eu lieu, triées
+func ConcertYears(index map[int][]Concert) []int {
+	seen := map[int]bool{}
+	for _, concerts := range index {
+		for _, c := range concerts {
+			seen[c.Date.Year()] = true
+		}
+	}
+	years := make([]int, 0, len(seen))
+	for y := range seen {
+		years = append(years, y)
+	}
+	sort.Ints(years)
+	return years
+}
+
+// LatestConcert renvoie la date du concert le plus récent (date nulle s'il n'y en a aucun)
+func LatestConcert(index map[int][]Concert) time.Time {
+	var latest time.Time
+	for _, concerts := range index {
+		if last := lastConcert(concerts); last.After(latest) {
+			latest = last
+		}
+	}
+	return latest
+}
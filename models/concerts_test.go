@@ -0,0 +1,28 @@
+package groupie_test
+
+import (
+	"testing"
+
+	api "groupie/models"
+)
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		raw, city, country string
+	}{
+		{"los_angeles-usa", "Los Angeles", "USA"},
+		{"london-uk", "London", "UK"},
+		{"ulm-germany", "Ulm", "Germany"}, // Nom court, pas un code de pays
+		{"rio-brazil", "Rio", "Brazil"},
+		{"são_paulo-brazil", "São Paulo", "Brazil"},
+		{"écully-france", "Écully", "France"},
+		{"playa_del_carmen-mexico", "Playa Del Carmen", "Mexico"},
+		{"nowhere", "Nowhere", ""},
+	}
+	for _, tt := range tests {
+		city, country := api.ParseLocation(tt.raw)
+		if city != tt.city || country != tt.country {
+			t.Errorf("ParseLocation(%q) = %q, %q, attendu %q, %q", tt.raw, city, country, tt.city, tt.country)
+		}
+	}
+}
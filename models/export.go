@@ -0,0 +1,309 @@
+package groupie
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// ExportFormat est un format d'export de la liste des artistes
+type ExportFormat string
+
+// Formats d'export disponibles
+const (
+	FormatCSV      ExportFormat = "csv"
+	FormatJSONL    ExportFormat = "jsonl"
+	FormatMarkdown ExportFormat = "md"
+	FormatJSON     ExportFormat = "json"  // Tableau JSON (ligne de commande)
+	FormatTable    ExportFormat = "table" // Tableau aligné pour le terminal
+)
+
+// ExportFormats liste les formats de fichier proposés à l'export, dans l'ordre d'affichage
+var ExportFormats = []ExportFormat{FormatCSV, FormatJSONL, FormatMarkdown}
+
+// Extension renvoie l'extension de fichier du format, ex: ".csv"
+func (f ExportFormat) Extension() string {
+	return "." + string(f)
+}
+
+// ParseExportFormat valide un format saisi (csv, json, jsonl, md, markdown, table)
+func ParseExportFormat(s string) (ExportFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "csv":
+		return FormatCSV, nil
+	case "json":
+		return FormatJSON, nil
+	case "jsonl":
+		return FormatJSONL, nil
+	case "md", "markdown":
+		return FormatMarkdown, nil
+	case "table":
+		return FormatTable, nil
+	}
+	return "", fmt.Errorf("format d'export inconnu : %q", s)
+}
+
+// Column est une colonne exportable
+// La valeur sert de clé JSON et de nom de colonne en ligne de commande
+type Column string
+
+// Colonnes disponibles
+const (
+	ColumnID         Column = "id"
+	ColumnName       Column = "name"
+	ColumnMembers    Column = "members"
+	ColumnCreation   Column = "creationDate"
+	ColumnFirstAlbum Column = "firstAlbum"
+	ColumnConcerts   Column = "concerts"
+	ColumnCountries  Column = "countries"
+)
+
+// Columns liste toutes les colonnes dans l'ordre d'export
+var Columns = []Column{ColumnID, ColumnName, ColumnMembers, ColumnCreation, ColumnFirstAlbum, ColumnConcerts, ColumnCountries}
+
+// columnLabels associe chaque colonne à son en-tête
+var columnLabels = map[Column]string{
+	ColumnID:         "ID",
+	ColumnName:       "Nom",
+	ColumnMembers:    "Membres",
+	ColumnCreation:   "Création",
+	ColumnFirstAlbum: "Premier album",
+	ColumnConcerts:   "Concerts",
+	ColumnCountries:  "Pays",
+}
+
+// Label renvoie l'en-tête de la colonne
+func (c Column) Label() string {
+	if l, ok := columnLabels[c]; ok {
+		return l
+	}
+	return string(c)
+}
+
+// ParseColumns lit une liste de colonnes séparées par des virgules, ex: "id,name,concerts"
+// Une liste vide renvoie toutes les colonnes
+func ParseColumns(s string) ([]Column, error) {
+	if strings.TrimSpace(s) == "" {
+		return Columns, nil
+	}
+	var cols []Column
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		found := false
+		for _, c := range Columns {
+			if strings.EqualFold(string(c), part) {
+				cols = append(cols, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("colonne inconnue : %q", part)
+		}
+	}
+	return cols, nil
+}
+
+// Countries renvoie les pays où l'artiste a joué, triés
+// La liste est vide, et non nil, sans concert : elle s'exporte en [] et non en null
+func Countries(concerts []Concert) []string {
+	seen := map[string]bool{}
+	countries := []string{}
+	for _, c := range concerts {
+		if c.Country != "" && !seen[c.Country] {
+			seen[c.Country] = true
+			countries = append(countries, c.Country)
+		}
+	}
+	sort.Strings(countries)
+	return countries
+}
+
+// ExportArtists écrit les artistes dans le format demandé, avec les colonnes choisies
+// concerts sert aux colonnes "concerts" et "countries"
+// label donne les en-têtes des formats Markdown et tableau, ex: traduits ; nil : Column.Label
+// Les formats CSV et JSON utilisent les noms de colonnes, indépendants de la langue
+func ExportArtists(w io.Writer, artists []Artist, concerts map[int][]Concert, format ExportFormat, columns []Column, label func(Column) string) error {
+	if len(columns) == 0 {
+		columns = Columns
+	}
+	if label == nil {
+		label = Column.Label
+	}
+	switch format {
+	case FormatCSV:
+		return exportCSV(w, artists, concerts, columns)
+	case FormatJSONL:
+		return exportJSONL(w, artists, concerts, columns)
+	case FormatMarkdown:
+		return exportMarkdown(w, artists, concerts, columns, label)
+	case FormatJSON:
+		return exportJSON(w, artists, concerts, columns)
+	case FormatTable:
+		return exportTable(w, artists, concerts, columns, label)
+	}
+	return fmt.Errorf("format d'export inconnu : %q", format)
+}
+
+// columnValue renvoie la valeur d'une colonne pour les formats JSON
+func columnValue(a Artist, concerts map[int][]Concert, c Column) interface{} {
+	switch c {
+	case ColumnID:
+		return a.ID
+	case ColumnName:
+		return a.Name
+	case ColumnMembers:
+		if a.Members == nil {
+			return []string{}
+		}
+		return a.Members
+	case ColumnCreation:
+		return a.CreationDate
+	case ColumnFirstAlbum:
+		return a.FirstAlbum
+	case ColumnConcerts:
+		return len(concerts[a.ID])
+	case ColumnCountries:
+		return Countries(concerts[a.ID])
+	}
+	return nil
+}
+
+// columnText renvoie la valeur d'une colonne sous forme de texte (CSV, Markdown)
+// Les listes sont séparées par "; "
+func columnText(a Artist, concerts map[int][]Concert, c Column) string {
+	switch v := columnValue(a, concerts, c).(type) {
+	case int:
+		return strconv.Itoa(v)
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, "; ")
+	}
+	return ""
+}
+
+func exportCSV(w io.Writer, artists []Artist, concerts map[int][]Concert, columns []Column) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = string(c)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, a := range artists {
+		row := make([]string, len(columns))
+		for i, c := range columns {
+			row[i] = columnText(a, concerts, c)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// jsonObject encode un artiste en objet JSON
+// Les clés sont écrites dans l'ordre des colonnes choisies
+func jsonObject(a Artist, concerts map[int][]Concert, columns []Column) (string, error) {
+	var b strings.Builder
+	b.WriteString("{")
+	for i, c := range columns {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		key, _ := json.Marshal(string(c))
+		value, err := json.Marshal(columnValue(a, concerts, c))
+		if err != nil {
+			return "", err
+		}
+		b.Write(key)
+		b.WriteString(":")
+		b.Write(value)
+	}
+	b.WriteString("}")
+	return b.String(), nil
+}
+
+// exportJSONL écrit un objet JSON par ligne (JSON Lines)
+func exportJSONL(w io.Writer, artists []Artist, concerts map[int][]Concert, columns []Column) error {
+	for _, a := range artists {
+		obj, err := jsonObject(a, concerts, columns)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, obj+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportJSON écrit un tableau JSON, un artiste par ligne
+func exportJSON(w io.Writer, artists []Artist, concerts map[int][]Concert, columns []Column) error {
+	var b strings.Builder
+	b.WriteString("[")
+	for i, a := range artists {
+		obj, err := jsonObject(a, concerts, columns)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n  " + obj)
+	}
+	b.WriteString("\n]\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// exportTable écrit un tableau aligné pour le terminal
+func exportTable(w io.Writer, artists []Artist, concerts map[int][]Concert, columns []Column, label func(Column) string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = strings.ToUpper(label(c))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, a := range artists {
+		row := make([]string, len(columns))
+		for i, c := range columns {
+			row[i] = columnText(a, concerts, c)
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// exportMarkdown écrit un tableau Markdown
+func exportMarkdown(w io.Writer, artists []Artist, concerts map[int][]Concert, columns []Column, label func(Column) string) error {
+	cell := strings.NewReplacer("|", `\|`, "\n", " ")
+
+	var b strings.Builder
+	b.WriteString("|")
+	for _, c := range columns {
+		b.WriteString(" " + cell.Replace(label(c)) + " |")
+	}
+	b.WriteString("\n|")
+	for range columns {
+		b.WriteString(" --- |")
+	}
+	b.WriteString("\n")
+	for _, a := range artists {
+		b.WriteString("|")
+		for _, c := range columns {
+			b.WriteString(" " + cell.Replace(columnText(a, concerts, c)) + " |")
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
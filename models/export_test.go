@@ -0,0 +1,60 @@
+package groupie_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	api "groupie/models"
+)
+
+func TestExportEmptyListsAsArrays(t *testing.T) {
+	// Artiste sans membres ni concerts : ses listes s'exportent en [], pas en null
+	artists := []api.Artist{{ID: 7, Name: "Inconnu"}}
+	columns := []api.Column{api.ColumnMembers, api.ColumnCountries}
+
+	for _, format := range []api.ExportFormat{api.FormatJSON, api.FormatJSONL} {
+		var out bytes.Buffer
+		if err := api.ExportArtists(&out, artists, map[int][]api.Concert{}, format, columns, nil); err != nil {
+			t.Fatal(err)
+		}
+		var row map[string]any
+		data := bytes.TrimSpace(out.Bytes())
+		if format == api.FormatJSON {
+			var rows []map[string]any
+			if err := json.Unmarshal(data, &rows); err != nil || len(rows) != 1 {
+				t.Fatalf("%s : %v\n%s", format, err, data)
+			}
+			row = rows[0]
+		} else if err := json.Unmarshal(data, &row); err != nil {
+			t.Fatalf("%s : %v\n%s", format, err, data)
+		}
+		for _, c := range columns {
+			if list, ok := row[string(c)].([]any); !ok || len(list) != 0 {
+				t.Errorf("%s : %s = %#v, attendu []", format, c, row[string(c)])
+			}
+		}
+	}
+}
+
+func TestExportMarkdownHeaders(t *testing.T) {
+	artists := []api.Artist{{ID: 1, Name: "Queen"}}
+	columns := []api.Column{api.ColumnID, api.ColumnName}
+	english := map[api.Column]string{api.ColumnID: "ID", api.ColumnName: "Name"}
+
+	for _, tc := range []struct {
+		label func(api.Column) string
+		want  string
+	}{
+		{nil, "| ID | Nom |\n| --- | --- |\n| 1 | Queen |\n"},
+		{func(c api.Column) string { return english[c] }, "| ID | Name |\n| --- | --- |\n| 1 | Queen |\n"},
+	} {
+		var out bytes.Buffer
+		if err := api.ExportArtists(&out, artists, nil, api.FormatMarkdown, columns, tc.label); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != tc.want {
+			t.Errorf("Markdown =\n%s\nattendu\n%s", out.String(), tc.want)
+		}
+	}
+}
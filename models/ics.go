@@ -0,0 +1,106 @@
+package groupie
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// CalendarEvent est un concert exporté au format iCalendar (RFC 5545)
+type CalendarEvent struct {
+	UID      string    // Identifiant unique et stable de l'événement
+	Date     time.Time // Jour du concert (événement sur la journée entière)
+	Summary  string    // Titre : nom de l'artiste
+	Location string    // Lieu lisible, ex: "Paris, France"
+	Lat, Lon float64   // Coordonnées du lieu
+	HasGeo   bool      // Les coordonnées sont connues
+}
+
+// GeoLookup renvoie les coordonnées connues d'un lieu, sans requête réseau
+type GeoLookup func(place string) (lat, lon float64, ok bool)
+
+// ConcertEvents crée un événement par concert de l'artiste
+// geo peut être nil si aucune coordonnée n'est connue
+func ConcertEvents(artist Artist, concerts []Concert, geo GeoLookup) []CalendarEvent {
+	events := make([]CalendarEvent, 0, len(concerts))
+	for _, c := range concerts {
+		e := CalendarEvent{
+			UID:      fmt.Sprintf("%d-%s-%s@groupie-tracker", artist.ID, c.Date.Format("20060102"), c.Location),
+			Date:     c.Date,
+			Summary:  artist.Name,
+			Location: c.Place(),
+		}
+		if geo != nil {
+			e.Lat, e.Lon, e.HasGeo = geo(c.Place())
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+// WriteICS écrit les événements dans un fichier .ics (RFC 5545)
+// stamp est la date de génération (DTSTAMP)
+func WriteICS(w io.Writer, events []CalendarEvent, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		bw.WriteString(foldLine(s))
+		bw.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Groupie Tracker//Concerts//FR")
+	line("CALSCALE:GREGORIAN")
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + escapeText(e.UID))
+		line("DTSTAMP:" + stamp.UTC().Format("20060102T150405Z"))
+		// Événement sur la journée : DTEND est le lendemain (borne exclue)
+		line("DTSTART;VALUE=DATE:" + e.Date.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + e.Date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + escapeText(e.Summary))
+		line("LOCATION:" + escapeText(e.Location))
+		if e.HasGeo {
+			line(fmt.Sprintf("GEO:%.6f;%.6f", e.Lat, e.Lon))
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// escapeText échappe une valeur TEXT : "\", ";", "," et les retours à la ligne
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// foldLine coupe les lignes de plus de 75 octets : la suite commence par une espace
+// La coupure ne tombe jamais au milieu d'un caractère UTF-8
+func foldLine(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+	var b strings.Builder
+	width := 0
+	max := limit
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > max {
+			b.WriteString("\r\n ")
+			width = 0
+			max = limit - 1 // L'espace de continuation compte dans la ligne
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package groupie_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	api "groupie/models"
+)
+
+func TestWriteICS(t *testing.T) {
+	event := api.CalendarEvent{
+		UID:      "1-20190301-paris-france@groupie-tracker",
+		Date:     time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC),
+		Summary:  `AC/DC, Queen; Back\Slash`,
+		Location: strings.Repeat("Saint-Étienne-du-Rouvray, ", 4) + "France",
+		Lat:      48.856613,
+		Lon:      2.352222,
+		HasGeo:   true,
+	}
+	var out bytes.Buffer
+	if err := api.WriteICS(&out, []api.CalendarEvent{event}, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	data := out.String()
+
+	// Chaque ligne se termine par CRLF, sans LF isolé
+	if !strings.HasSuffix(data, "END:VCALENDAR\r\n") {
+		t.Errorf("fin du fichier = %q", data[max(0, len(data)-20):])
+	}
+	if n := strings.Count(data, "\n"); n != strings.Count(data, "\r\n") {
+		t.Errorf("%d LF pour %d CRLF", n, strings.Count(data, "\r\n"))
+	}
+
+	// Les lignes pliées font au plus 75 octets, sans couper un caractère UTF-8
+	lines := strings.Split(strings.TrimSuffix(data, "\r\n"), "\r\n")
+	for _, l := range lines {
+		if len(l) > 75 || !utf8.ValidString(l) {
+			t.Errorf("ligne mal pliée (%d octets) : %q", len(l), l)
+		}
+	}
+
+	// Une fois dépliées, les valeurs sont échappées
+	unfolded := strings.ReplaceAll(data, "\r\n ", "")
+	for _, want := range []string{
+		`SUMMARY:AC/DC\, Queen\; Back\\Slash` + "\r\n",
+		"LOCATION:" + strings.Repeat(`Saint-Étienne-du-Rouvray\, `, 4) + "France\r\n",
+		"DTSTART;VALUE=DATE:20190301\r\n",
+		"DTEND;VALUE=DATE:20190302\r\n",
+		"DTSTAMP:20240102T030405Z\r\n",
+		"GEO:48.856613;2.352222\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("%q absent de\n%s", want, unfolded)
+		}
+	}
+	if !strings.Contains(data, "\r\n ") {
+		t.Error("la ligne LOCATION de plus de 75 octets n'est pas pliée")
+	}
+}
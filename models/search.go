@@ -0,0 +1,148 @@
+package groupie
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Champs de recherche reconnus dans une requête "champ:valeur"
+const (
+	FieldAny      = ""         // Texte libre : nom, membres, lieux, premier album, création
+	FieldName     = "name"     // Nom de l'artiste
+	FieldMember   = "member"   // Un des membres
+	FieldLocation = "location" // Lieu de concert (ville ou pays)
+	FieldAlbum    = "album"    // Date du premier album
+	FieldCreated  = "created"  // Année de création
+)
+
+// fieldAliases associe les noms de champs acceptés à leur champ
+var fieldAliases = map[string]string{
+	"name":     FieldName,
+	"nom":      FieldName,
+	"member":   FieldMember,
+	"membre":   FieldMember,
+	"location": FieldLocation,
+	"lieu":     FieldLocation,
+	"city":     FieldLocation,
+	"country":  FieldLocation,
+	"album":    FieldAlbum,
+	"created":  FieldCreated,
+	"creation": FieldCreated,
+}
+
+// Term est un critère de recherche : un champ et la valeur cherchée (en minuscules)
+type Term struct {
+	Field string
+	Value string
+}
+
+// Query est une requête de recherche : tous les critères doivent correspondre
+// Exemple : `member:freddie location:"new york" 1970`
+type Query []Term
+
+// ParseQuery lit une requête de recherche
+// Les valeurs contenant des espaces s'écrivent entre guillemets
+// Un préfixe inconnu (ex: "ac:dc") est traité comme du texte libre
+func ParseQuery(s string) Query {
+	var q Query
+	for _, token := range tokenize(s) {
+		term := Term{Field: FieldAny, Value: token}
+		if i := strings.Index(token, ":"); i > 0 {
+			if field, ok := fieldAliases[strings.ToLower(token[:i])]; ok {
+				term = Term{Field: field, Value: token[i+1:]}
+			}
+		}
+		term.Value = strings.ToLower(strings.Trim(term.Value, `"`))
+		if term.Value != "" {
+			q = append(q, term)
+		}
+	}
+	return q
+}
+
+// tokenize découpe une requête sur les espaces, sauf entre guillemets
+func tokenize(s string) []string {
+	var tokens []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+// Match indique si l'artiste correspond à tous les critères
+// concerts sert au champ "location"
+func (q Query) Match(a Artist, concerts []Concert) bool {
+	for _, t := range q {
+		if !t.match(a, concerts) {
+			return false
+		}
+	}
+	return true
+}
+
+// FreeTerms renvoie les termes cherchés dans les noms d'artistes et de membres, pour le tri par pertinence
+// Ex: "member:freddie location:london queen" donne ["freddie", "queen"]
+func (q Query) FreeTerms() []string {
+	var free []string
+	for _, t := range q {
+		if t.Field == FieldAny || t.Field == FieldName || t.Field == FieldMember {
+			free = append(free, t.Value)
+		}
+	}
+	return free
+}
+
+func (t Term) match(a Artist, concerts []Concert) bool {
+	contains := func(s string) bool { return strings.Contains(strings.ToLower(s), t.Value) }
+
+	name := contains(a.Name)
+	member := false
+	for _, m := range a.Members {
+		if contains(m) {
+			member = true
+			break
+		}
+	}
+	location := false
+	if t.Field == FieldLocation || t.Field == FieldAny {
+		for _, c := range concerts {
+			if contains(c.Place()) || contains(c.Location) {
+				location = true
+				break
+			}
+		}
+	}
+	album := contains(a.FirstAlbum)
+	created := contains(fmt.Sprint(a.CreationDate))
+
+	switch t.Field {
+	case FieldName:
+		return name
+	case FieldMember:
+		return member
+	case FieldLocation:
+		return location
+	case FieldAlbum:
+		return album
+	case FieldCreated:
+		return created
+	}
+	return name || member || location || album || created
+}
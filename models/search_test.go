@@ -0,0 +1,76 @@
+package groupie_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	api "groupie/models"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		in   string
+		want api.Query
+	}{
+		{"", nil},
+		{"Queen", api.Query{{Field: api.FieldAny, Value: "queen"}}},
+		{`membre:Freddie lieu:"New York" 1970`, api.Query{
+			{Field: api.FieldMember, Value: "freddie"},
+			{Field: api.FieldLocation, Value: "new york"},
+			{Field: api.FieldAny, Value: "1970"},
+		}},
+		{"ac:dc", api.Query{{Field: api.FieldAny, Value: "ac:dc"}}},
+		{"name:", nil},
+	}
+	for _, tt := range tests {
+		if got := api.ParseQuery(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseQuery(%q) = %+v, attendu %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestQueryMatch(t *testing.T) {
+	queen := api.Artist{Name: "Queen", Members: []string{"Freddie Mercury", "Brian May"}, FirstAlbum: "14-12-1973", CreationDate: 1970}
+	concerts := api.ConcertsFromRelation(api.RelationData{DatesLocations: map[string][]string{
+		"osaka-japan": {"28-01-2020"},
+	}})
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"queen", true},
+		{"name:freddie", false},
+		{"member:freddie", true},
+		{"location:japan", true},
+		{"osaka", true},
+		{"created:1970 album:1973", true},
+		{"member:freddie created:1980", false},
+	}
+	for _, tt := range tests {
+		if got := api.ParseQuery(tt.query).Match(queen, concerts); got != tt.want {
+			t.Errorf("%q : %v, attendu %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestPlayedBetween(t *testing.T) {
+	concerts := api.ConcertsFromRelation(api.RelationData{DatesLocations: map[string][]string{
+		"paris-france":       {"12-05-2019", "01-01-2021"},
+		"north_carolina-usa": {"07-04-2019"},
+	}})
+	from, _ := api.ParseDateBound("2019", false)
+	to, _ := api.ParseDateBound("2019", true)
+
+	got := api.PlayedBetween(concerts, from, to, "")
+	if len(got) != 2 || got[0].Place() != "North Carolina, USA" || got[1].Place() != "Paris, France" {
+		t.Errorf("2019 : %+v", got)
+	}
+	if got := api.PlayedBetween(concerts, time.Time{}, time.Time{}, "france"); len(got) != 2 {
+		t.Errorf("France : %d concert(s), attendu 2", len(got))
+	}
+	if _, err := api.ParseDateBound("2019-05", false); err == nil {
+		t.Error("borne invalide acceptée")
+	}
+}
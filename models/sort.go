@@ -0,0 +1,144 @@
+package groupie
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// SortOrder identifie un ordre de tri de la liste des artistes
+// La valeur est stable : elle est sauvegardée dans les préférences
+type SortOrder string
+
+// Ordres de tri disponibles
+const (
+	SortNameAsc       SortOrder = "name"
+	SortNameDesc      SortOrder = "name-desc"
+	SortCreation      SortOrder = "created"
+	SortFirstAlbum    SortOrder = "album"
+	SortMembers       SortOrder = "members"
+	SortConcerts      SortOrder = "concerts"
+	SortRecentConcert SortOrder = "recent"
+	SortRelevance     SortOrder = "relevance"
+)
+
+// SortOrders liste les ordres de tri dans l'ordre d'affichage du sélecteur
+var SortOrders = []SortOrder{
+	SortNameAsc,
+	SortNameDesc,
+	SortCreation,
+	SortFirstAlbum,
+	SortMembers,
+	SortConcerts,
+	SortRecentConcert,
+	SortRelevance,
+}
+
+// sortLabels associe chaque ordre de tri à son libellé
+var sortLabels = map[SortOrder]string{
+	SortNameAsc:       "Nom (A–Z)",
+	SortNameDesc:      "Nom (Z–A)",
+	SortCreation:      "Année de création",
+	SortFirstAlbum:    "Premier album",
+	SortMembers:       "Nombre de membres",
+	SortConcerts:      "Nombre de concerts",
+	SortRecentConcert: "Concert le plus récent",
+	SortRelevance:     "Pertinence",
+}
+
+// Label renvoie le libellé français de l'ordre de tri (traduit par l'interface graphique)
+func (o SortOrder) Label() string {
+	if l, ok := sortLabels[o]; ok {
+		return l
+	}
+	return string(o)
+}
+
+// ParseSortOrder valide un ordre de tri sauvegardé ou saisi en ligne de commande
+func ParseSortOrder(s string) (SortOrder, bool) {
+	o := SortOrder(strings.ToLower(strings.TrimSpace(s)))
+	_, ok := sortLabels[o]
+	return o, ok
+}
+
+// Relevance calcule un score de pertinence d'un artiste pour les termes d'une recherche
+// Chaque terme compte : nom exact > début du nom > nom contenant le terme > membre > reste
+func Relevance(a Artist, terms []string) int {
+	score := 0
+	for _, term := range terms {
+		score += termRelevance(a, term)
+	}
+	return score
+}
+
+// termRelevance calcule le score d'un seul terme de recherche
+func termRelevance(a Artist, query string) int {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return 0
+	}
+	name := strings.ToLower(a.Name)
+	switch {
+	case name == query:
+		return 100
+	case strings.HasPrefix(name, query):
+		return 80
+	case strings.Contains(name, query):
+		return 60
+	}
+	for _, m := range a.Members {
+		if strings.Contains(strings.ToLower(m), query) {
+			return 40
+		}
+	}
+	return 0
+}
+
+// SortArtists trie les artistes selon l'ordre demandé
+// Le tri est stable : à égalité, les artistes restent triés par nom
+// concerts sert aux tris par concerts, terms au tri par pertinence
+func SortArtists(artists []Artist, order SortOrder, concerts map[int][]Concert, terms []string) {
+	// Ordre de base par nom, conservé pour départager les égalités
+	sort.SliceStable(artists, func(i, j int) bool {
+		return strings.ToLower(artists[i].Name) < strings.ToLower(artists[j].Name)
+	})
+
+	var less func(a, b Artist) bool
+	switch order {
+	case SortNameDesc:
+		less = func(a, b Artist) bool { return strings.ToLower(a.Name) > strings.ToLower(b.Name) }
+	case SortCreation:
+		less = func(a, b Artist) bool { return a.CreationDate < b.CreationDate }
+	case SortFirstAlbum:
+		less = func(a, b Artist) bool { return firstAlbumDate(a).Before(firstAlbumDate(b)) }
+	case SortMembers:
+		less = func(a, b Artist) bool { return len(a.Members) < len(b.Members) }
+	case SortConcerts:
+		less = func(a, b Artist) bool { return len(concerts[a.ID]) > len(concerts[b.ID]) }
+	case SortRecentConcert:
+		less = func(a, b Artist) bool { return lastConcert(concerts[a.ID]).After(lastConcert(concerts[b.ID])) }
+	case SortRelevance:
+		less = func(a, b Artist) bool { return Relevance(a, terms) > Relevance(b, terms) }
+	default:
+		return
+	}
+
+	sort.SliceStable(artists, func(i, j int) bool {
+		return less(artists[i], artists[j])
+	})
+}
+
+// firstAlbumDate convertit la date du premier album (JJ-MM-AAAA)
+// Une date illisible est considérée comme nulle
+func firstAlbumDate(a Artist) time.Time {
+	t, _ := time.Parse(DateLayout, a.FirstAlbum)
+	return t
+}
+
+// lastConcert renvoie la date du dernier concert (les concerts sont triés par date)
+func lastConcert(concerts []Concert) time.Time {
+	if len(concerts) == 0 {
+		return time.Time{}
+	}
+	return concerts[len(concerts)-1].Date
+}
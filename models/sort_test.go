@@ -0,0 +1,74 @@
+package groupie_test
+
+import (
+	"reflect"
+	"testing"
+
+	api "groupie/models"
+)
+
+// names renvoie les noms des artistes, dans l'ordre
+func names(artists []api.Artist) []string {
+	res := make([]string, len(artists))
+	for i, a := range artists {
+		res[i] = a.Name
+	}
+	return res
+}
+
+func TestRelevanceSumsTerms(t *testing.T) {
+	artists := []api.Artist{
+		{ID: 1, Name: "Ace of Queens", Members: []string{"Ann Smith"}},
+		{ID: 2, Name: "Pink Floyd", Members: []string{"Roger Waters"}},
+		{ID: 3, Name: "Queen", Members: []string{"Freddie Mercury"}},
+	}
+	terms := api.ParseQuery("freddie queen").FreeTerms()
+	if got := api.Relevance(artists[2], terms); got != 140 {
+		t.Errorf("Relevance(Queen) = %d, attendu 140 (membre + nom exact)", got)
+	}
+
+	api.SortArtists(artists, api.SortRelevance, nil, terms)
+	want := []string{"Queen", "Ace of Queens", "Pink Floyd"}
+	if got := names(artists); !reflect.DeepEqual(got, want) {
+		t.Errorf("tri par pertinence = %v, attendu %v", got, want)
+	}
+}
+
+func TestSortArtists(t *testing.T) {
+	artists := []api.Artist{
+		{ID: 3, Name: "gamma", CreationDate: 1970, FirstAlbum: "01-01-1990", Members: []string{"a", "b", "c", "d"}},
+		{ID: 4, Name: "Delta", CreationDate: 1990, FirstAlbum: "inconnu", Members: []string{"a"}},
+		{ID: 1, Name: "beta", CreationDate: 1980, FirstAlbum: "01-01-1985", Members: []string{"a", "b"}},
+		{ID: 2, Name: "Alpha", CreationDate: 1970, FirstAlbum: "01-01-1975", Members: []string{"a", "b"}},
+	}
+	concerts := map[int][]api.Concert{
+		1: {concert(1, "01-01-2019", "Paris", "France")},
+		2: {concert(2, "01-01-2016", "Paris", "France"), concert(2, "01-01-2017", "Lyon", "France"), concert(2, "01-01-2018", "Nice", "France")},
+		3: {concert(3, "01-01-2020", "Berlin", "Germany")},
+	}
+
+	// À égalité, l'ordre alphabétique sans tenir compte de la casse départage
+	tests := []struct {
+		order api.SortOrder
+		terms []string
+		want  []string
+	}{
+		{api.SortNameAsc, nil, []string{"Alpha", "beta", "Delta", "gamma"}},
+		{api.SortNameDesc, nil, []string{"gamma", "Delta", "beta", "Alpha"}},
+		{api.SortCreation, nil, []string{"Alpha", "gamma", "beta", "Delta"}},
+		{api.SortFirstAlbum, nil, []string{"Delta", "Alpha", "beta", "gamma"}}, // Date illisible en premier
+		{api.SortMembers, nil, []string{"Delta", "Alpha", "beta", "gamma"}},
+		{api.SortConcerts, nil, []string{"Alpha", "beta", "gamma", "Delta"}},
+		{api.SortRecentConcert, nil, []string{"gamma", "beta", "Alpha", "Delta"}},
+		{api.SortRelevance, []string{"delta"}, []string{"Delta", "Alpha", "beta", "gamma"}},
+		{api.SortRelevance, nil, []string{"Alpha", "beta", "Delta", "gamma"}},
+		{"inconnu", nil, []string{"Alpha", "beta", "Delta", "gamma"}},
+	}
+	for _, tt := range tests {
+		sorted := append([]api.Artist(nil), artists...)
+		api.SortArtists(sorted, tt.order, concerts, tt.terms)
+		if got := names(sorted); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SortArtists(%s, %q) = %v, attendu %v", tt.order, tt.terms, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package groupie
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Bucket est une barre de graphique : un libellé, une valeur et une clé
+// La clé permet de retrouver ce que représente la barre (décennie, année, nombre de membres...)
+type Bucket struct {
+	Key   int    `json:"key"`   // Décennie, écart en années, nombre de membres ou année
+	Label string `json:"label"` // Libellé affiché, en français
+	Value int    `json:"value"` // Valeur de la barre
+}
+
+// Stats regroupe les statistiques calculées sur l'ensemble des artistes
+type Stats struct {
+	Decades         []Bucket `json:"decades"`         // Artistes créés par décennie
+	AlbumGaps       []Bucket `json:"albumGaps"`       // Années entre la création et le premier album
+	Members         []Bucket `json:"members"`         // Répartition du nombre de membres
+	TopCities       []Bucket `json:"topCities"`       // Lieux avec le plus de concerts
+	TopCountries    []Bucket `json:"topCountries"`    // Pays avec le plus de concerts
+	ConcertsPerYear []Bucket `json:"concertsPerYear"` // Concerts par année
+}
+
+// Nombre de lieux et de pays gardés dans les classements
+const topPlaces = 20
+
+// ComputeStats calcule les statistiques à partir des artistes et de leurs concerts
+// Aucune requête n'est faite : tout vient des données déjà chargées
+func ComputeStats(artists []Artist, concerts map[int][]Concert) Stats {
+	decades := map[int]int{}
+	gaps := map[int]int{}
+	members := map[int]int{}
+	cities := map[string]int{}
+	countries := map[string]int{}
+	years := map[int]int{}
+
+	for _, a := range artists {
+		decades[a.CreationDate/10*10]++
+		if gap, ok := AlbumGap(a); ok {
+			gaps[gap]++
+		}
+		members[len(a.Members)]++
+		for _, c := range concerts[a.ID] {
+			cities[c.Place()]++
+			if c.Country != "" {
+				countries[c.Country]++
+			}
+			years[c.Date.Year()]++
+		}
+	}
+
+	return Stats{
+		Decades:         intBuckets(decades, func(k int) string { return fmt.Sprintf("Années %d", k) }),
+		AlbumGaps:       intBuckets(gaps, func(k int) string { return fmt.Sprintf("%d an(s)", k) }),
+		Members:         intBuckets(members, func(k int) string { return fmt.Sprintf("%d membre(s)", k) }),
+		TopCities:       topBuckets(cities, topPlaces),
+		TopCountries:    topBuckets(countries, topPlaces),
+		ConcertsPerYear: intBuckets(years, func(k int) string { return fmt.Sprint(k) }),
+	}
+}
+
+// AlbumGap renvoie le nombre d'années entre la création et le premier album
+func AlbumGap(a Artist) (int, bool) {
+	album := firstAlbumDate(a)
+	if album.IsZero() || a.CreationDate == 0 {
+		return 0, false
+	}
+	return album.Year() - a.CreationDate, true
+}
+
+// intBuckets transforme des compteurs en barres triées par clé
+func intBuckets(counts map[int]int, label func(int) string) []Bucket {
+	buckets := make([]Bucket, 0, len(counts))
+	for k, v := range counts {
+		buckets = append(buckets, Bucket{Key: k, Label: label(k), Value: v})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Key < buckets[j].Key })
+	return buckets
+}
+
+// topBuckets garde les n libellés les plus fréquents, du plus grand au plus petit
+func topBuckets(counts map[string]int, n int) []Bucket {
+	buckets := make([]Bucket, 0, len(counts))
+	for k, v := range counts {
+		buckets = append(buckets, Bucket{Label: k, Value: v})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Value != buckets[j].Value {
+			return buckets[i].Value > buckets[j].Value
+		}
+		return buckets[i].Label < buckets[j].Label
+	})
+	if len(buckets) > n {
+		buckets = buckets[:n]
+	}
+	return buckets
+}
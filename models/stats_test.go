@@ -0,0 +1,82 @@
+package groupie_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	api "groupie/models"
+)
+
+func TestComputeStats(t *testing.T) {
+	artists := []api.Artist{
+		{ID: 1, Name: "Queen", CreationDate: 1970, FirstAlbum: "14-12-1973", Members: []string{"Freddie Mercury", "Brian May"}},
+		{ID: 2, Name: "Pink Floyd", CreationDate: 1965, FirstAlbum: "05-08-1967", Members: []string{"Roger Waters", "David Gilmour"}},
+		{ID: 3, Name: "SOJA", CreationDate: 1997, FirstAlbum: "inconnu", Members: []string{"Jacob Hemphill"}},
+	}
+	concerts := map[int][]api.Concert{
+		1: {concert(1, "01-03-2019", "Paris", "France"), concert(1, "01-09-2019", "Lyon", "France")},
+		2: {concert(2, "12-05-2018", "Paris", "France"), concert(2, "13-05-2018", "Berlin", "Germany")},
+		3: {concert(3, "01-01-2020", "Atlantis", "")},
+	}
+	stats := api.ComputeStats(artists, concerts)
+
+	tests := []struct {
+		name string
+		got  []api.Bucket
+		want []api.Bucket
+	}{
+		{"Decades", stats.Decades, []api.Bucket{
+			{Key: 1960, Label: "Années 1960", Value: 1},
+			{Key: 1970, Label: "Années 1970", Value: 1},
+			{Key: 1990, Label: "Années 1990", Value: 1},
+		}},
+		// SOJA n'a pas de date de premier album lisible : il n'est pas compté
+		{"AlbumGaps", stats.AlbumGaps, []api.Bucket{
+			{Key: 2, Label: "2 an(s)", Value: 1},
+			{Key: 3, Label: "3 an(s)", Value: 1},
+		}},
+		{"Members", stats.Members, []api.Bucket{
+			{Key: 1, Label: "1 membre(s)", Value: 1},
+			{Key: 2, Label: "2 membre(s)", Value: 2},
+		}},
+		// Du plus fréquent au moins fréquent, puis par ordre alphabétique
+		{"TopCities", stats.TopCities, []api.Bucket{
+			{Label: "Paris, France", Value: 2},
+			{Label: "Atlantis", Value: 1},
+			{Label: "Berlin, Germany", Value: 1},
+			{Label: "Lyon, France", Value: 1},
+		}},
+		{"TopCountries", stats.TopCountries, []api.Bucket{
+			{Label: "France", Value: 3},
+			{Label: "Germany", Value: 1},
+		}},
+		{"ConcertsPerYear", stats.ConcertsPerYear, []api.Bucket{
+			{Key: 2018, Label: "2018", Value: 2},
+			{Key: 2019, Label: "2019", Value: 2},
+			{Key: 2020, Label: "2020", Value: 1},
+		}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %+v\nattendu %+v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestComputeStatsTopPlaces(t *testing.T) {
+	// Seuls les 20 lieux les plus fréquents sont gardés
+	var list []api.Concert
+	for i := 0; i < 25; i++ {
+		for n := 0; n <= i; n++ {
+			list = append(list, concert(1, "01-01-2019", fmt.Sprintf("Ville %02d", i), "France"))
+		}
+	}
+	stats := api.ComputeStats([]api.Artist{{ID: 1, Name: "Queen"}}, map[int][]api.Concert{1: list})
+	if len(stats.TopCities) != 20 {
+		t.Fatalf("%d lieux, attendu 20", len(stats.TopCities))
+	}
+	if first, last := stats.TopCities[0], stats.TopCities[19]; first.Label != "Ville 24, France" || last.Label != "Ville 05, France" {
+		t.Errorf("classement de %s à %s, attendu de Ville 24 à Ville 05", first.Label, last.Label)
+	}
+}
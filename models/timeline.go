@@ -0,0 +1,84 @@
+package groupie
+
+import "time"
+
+// Seuils de la chronologie, en jours
+const (
+	BackToBackDays = 1  // Concerts enchaînés : au plus un jour d'écart
+	LongGapDays    = 90 // Pause marquée : au moins trois mois sans concert
+)
+
+// MonthNames sont les noms des mois en français, clés des catalogues de l'interface graphique
+var MonthNames = [...]string{
+	"Janvier", "Février", "Mars", "Avril", "Mai", "Juin",
+	"Juillet", "Août", "Septembre", "Octobre", "Novembre", "Décembre",
+}
+
+// Formats des pauses de la chronologie, à compléter par un nombre
+const (
+	GapDaysFormat   = "%d jours sans concert"
+	GapMonthsFormat = "%d mois sans concert"
+)
+
+// GapFormat renvoie le format d'une pause entre deux concerts et le nombre à y placer
+// Ex: 125 jours donne GapMonthsFormat et 4, pour "4 mois sans concert"
+func GapFormat(days int) (string, int) {
+	if days >= 60 {
+		return GapMonthsFormat, days / 30
+	}
+	return GapDaysFormat, days
+}
+
+// TimelineEntry est un concert placé dans la chronologie d'une tournée
+type TimelineEntry struct {
+	Concert
+	DaysSincePrevious int  // Jours depuis le concert précédent (0 pour le premier)
+	LongGap           bool // Une longue pause précède ce concert
+	BackToBack        bool // Concert enchaîné avec le précédent ou le suivant
+}
+
+// TimelineMonth regroupe les concerts d'un même mois
+type TimelineMonth struct {
+	Year    int
+	Month   time.Month
+	Entries []TimelineEntry
+}
+
+// BuildTimeline trie les concerts par date et les regroupe par année et par mois
+// Les longues pauses et les concerts enchaînés sont marqués
+func BuildTimeline(concerts []Concert) []TimelineMonth {
+	sorted := make([]Concert, len(concerts))
+	copy(sorted, concerts)
+	sortConcerts(sorted)
+
+	entries := make([]TimelineEntry, len(sorted))
+	for i, c := range sorted {
+		entries[i].Concert = c
+		if i == 0 {
+			continue
+		}
+		days := daysBetween(sorted[i-1].Date, c.Date)
+		entries[i].DaysSincePrevious = days
+		entries[i].LongGap = days >= LongGapDays
+		if days <= BackToBackDays {
+			entries[i].BackToBack = true
+			entries[i-1].BackToBack = true
+		}
+	}
+
+	var months []TimelineMonth
+	for _, e := range entries {
+		n := len(months)
+		if n == 0 || months[n-1].Year != e.Date.Year() || months[n-1].Month != e.Date.Month() {
+			months = append(months, TimelineMonth{Year: e.Date.Year(), Month: e.Date.Month()})
+			n++
+		}
+		months[n-1].Entries = append(months[n-1].Entries, e)
+	}
+	return months
+}
+
+// daysBetween renvoie le nombre de jours entiers entre deux dates
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
@@ -0,0 +1,89 @@
+package groupie_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	api "groupie/models"
+)
+
+func TestBuildTimeline(t *testing.T) {
+	// Concerts dans le désordre, comme ils sortent de la map des relations
+	concerts := []api.Concert{
+		concert(1, "15-01-2020", "Tokyo", "Japan"),
+		concert(1, "02-03-2019", "Lyon", "France"),
+		concert(1, "20-03-2019", "Nice", "France"),
+		concert(1, "01-03-2019", "Paris", "France"),
+		concert(1, "10-04-2019", "Berlin", "Germany"),
+	}
+	months := api.BuildTimeline(concerts)
+
+	type month struct {
+		Year  int
+		Month time.Month
+		Dates []string
+	}
+	var got []month
+	for _, m := range months {
+		got = append(got, month{Year: m.Year, Month: m.Month})
+		for _, e := range m.Entries {
+			got[len(got)-1].Dates = append(got[len(got)-1].Dates, e.Date.Format("02-01-2006"))
+		}
+	}
+	want := []month{
+		{2019, time.March, []string{"01-03-2019", "02-03-2019", "20-03-2019"}},
+		{2019, time.April, []string{"10-04-2019"}},
+		{2020, time.January, []string{"15-01-2020"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("chronologie = %+v\nattendu %+v", got, want)
+	}
+
+	// Écarts, concerts enchaînés et longues pauses
+	tests := []struct {
+		entry      api.TimelineEntry
+		days       int
+		backToBack bool
+		longGap    bool
+	}{
+		{months[0].Entries[0], 0, true, false},
+		{months[0].Entries[1], 1, true, false},
+		{months[0].Entries[2], 18, false, false},
+		{months[1].Entries[0], 21, false, false},
+		{months[2].Entries[0], 280, false, true},
+	}
+	for _, tt := range tests {
+		e := tt.entry
+		if e.DaysSincePrevious != tt.days || e.BackToBack != tt.backToBack || e.LongGap != tt.longGap {
+			t.Errorf("%s : %d jours, enchaîné %v, pause %v ; attendu %d, %v, %v",
+				e.City, e.DaysSincePrevious, e.BackToBack, e.LongGap, tt.days, tt.backToBack, tt.longGap)
+		}
+	}
+}
+
+func TestGapFormat(t *testing.T) {
+	tests := []struct {
+		days   int
+		format string
+		n      int
+	}{
+		{21, api.GapDaysFormat, 21},
+		{59, api.GapDaysFormat, 59},
+		{60, api.GapMonthsFormat, 2},
+		{280, api.GapMonthsFormat, 9},
+	}
+	for _, tt := range tests {
+		if format, n := api.GapFormat(tt.days); format != tt.format || n != tt.n {
+			t.Errorf("GapFormat(%d) = %q, %d ; attendu %q, %d", tt.days, format, n, tt.format, tt.n)
+		}
+	}
+}
+
+func TestBuildTimelineKeepsInput(t *testing.T) {
+	concerts := []api.Concert{concert(1, "02-03-2019", "Lyon", "France"), concert(1, "01-03-2019", "Paris", "France")}
+	api.BuildTimeline(concerts)
+	if concerts[0].City != "Lyon" {
+		t.Error("BuildTimeline a trié la liste reçue")
+	}
+}
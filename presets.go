@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/lang"
+)
+
+// Clé des préférences contenant les recherches enregistrées, en JSON
+const prefPresets = "presets"
+
+// Preset est une recherche enregistrée sous un nom, ex: "Groupes japonais des années 80"
+type Preset struct {
+	Name  string      `json:"name"`
+	State FilterState `json:"state"`
+}
+
+// Share renvoie la recherche sous forme de texte JSON compact, à copier et partager
+func (p Preset) Share() string {
+	data, _ := json.Marshal(p)
+	return string(data)
+}
+
+// ParsePreset lit une recherche partagée avec Share
+func ParsePreset(text string) (Preset, error) {
+	var p Preset
+	if err := json.Unmarshal([]byte(strings.TrimSpace(text)), &p); err != nil {
+		return Preset{}, fmt.Errorf(lang.L("recherche partagée invalide : %s"), err)
+	}
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return Preset{}, errors.New(lang.L("recherche partagée invalide : nom manquant"))
+	}
+	return p, nil
+}
+
+// presets garde les recherches enregistrées, dans l'ordre de création
+// Chaque modification est sauvegardée immédiatement dans les préférences
+type presets struct {
+	prefs fyne.Preferences
+	list  []Preset
+}
+
+// loadPresets relit les recherches enregistrées ; des préférences illisibles sont ignorées
+func loadPresets(prefs fyne.Preferences) *presets {
+	p := &presets{prefs: prefs}
+	if data := prefs.String(prefPresets); data != "" {
+		if err := json.Unmarshal([]byte(data), &p.list); err != nil {
+			p.list = nil
+		}
+	}
+	return p
+}
+
+// All renvoie les recherches enregistrées
+func (p *presets) All() []Preset {
+	return p.list
+}
+
+// Get renvoie la recherche enregistrée sous ce nom
+func (p *presets) Get(name string) (Preset, bool) {
+	for _, preset := range p.list {
+		if preset.Name == name {
+			return preset, true
+		}
+	}
+	return Preset{}, false
+}
+
+// FreeName renvoie name s'il n'est pas pris, sinon name suivi du premier numéro libre, ex: "Queen (2)"
+func (p *presets) FreeName(name string) string {
+	free := name
+	for n := 2; ; n++ {
+		if _, taken := p.Get(free); !taken {
+			return free
+		}
+		free = fmt.Sprintf("%s (%d)", name, n)
+	}
+}
+
+// Save enregistre une recherche ; une recherche du même nom est remplacée
+func (p *presets) Save(preset Preset) {
+	for i := range p.list {
+		if p.list[i].Name == preset.Name {
+			p.list[i] = preset
+			p.save()
+			return
+		}
+	}
+	p.list = append(p.list, preset)
+	p.save()
+}
+
+// Delete supprime la recherche enregistrée sous ce nom
+func (p *presets) Delete(name string) {
+	for i := range p.list {
+		if p.list[i].Name == name {
+			p.list = append(p.list[:i], p.list[i+1:]...)
+			p.save()
+			return
+		}
+	}
+}
+
+// save sauvegarde les recherches dans les préférences
+func (p *presets) save() {
+	data, _ := json.Marshal(p.list)
+	p.prefs.SetString(prefPresets, string(data))
+}
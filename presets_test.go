@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+
+	api "groupie/models"
+)
+
+func TestPresetsPersist(t *testing.T) {
+	prefs := test.NewTempApp(t).Preferences()
+	japan := Preset{Name: "Groupes japonais des années 80", State: FilterState{Place: "japan", From: "1980", To: "1989"}}
+
+	p := loadPresets(prefs)
+	p.Save(japan)
+	p.Save(Preset{Name: "Queen", State: FilterState{Query: "queen"}})
+	p.Save(Preset{Name: "Queen", State: FilterState{Query: "queen", FavOnly: true}})
+
+	// Relues depuis les préférences : le second « Queen » a remplacé le premier
+	p = loadPresets(prefs)
+	if got := p.All(); len(got) != 2 || got[0].Name != japan.Name {
+		t.Fatalf("recherches = %+v", got)
+	}
+	if q, ok := p.Get("Queen"); !ok || !q.State.FavOnly {
+		t.Errorf("Queen = %+v, %v", q, ok)
+	}
+
+	p.Delete(japan.Name)
+	if got := loadPresets(prefs).All(); len(got) != 1 || got[0].Name != "Queen" {
+		t.Errorf("après suppression : %+v", got)
+	}
+}
+
+func TestPresetShare(t *testing.T) {
+	p := Preset{Name: "Années 60", State: FilterState{
+		Query:  "19",
+		Fields: map[string]bool{api.FieldCreated: true},
+		Sort:   api.SortCreation,
+	}}
+	got, err := ParsePreset(p.Share())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != p.Name || got.State.Query != "19" || !got.State.Fields[api.FieldCreated] || got.State.Sort != api.SortCreation {
+		t.Errorf("relu = %+v", got)
+	}
+
+	for _, text := range []string{"", "groupie://list", `{"state":{"query":"x"}}`} {
+		if _, err := ParsePreset(text); err == nil {
+			t.Errorf("ParsePreset(%q) : erreur attendue", text)
+		}
+	}
+}
+
+func TestPresetFreeName(t *testing.T) {
+	p := loadPresets(test.NewTempApp(t).Preferences())
+	if got := p.FreeName("Queen"); got != "Queen" {
+		t.Errorf("FreeName sans doublon = %q", got)
+	}
+	p.Save(Preset{Name: "Queen"})
+	p.Save(Preset{Name: "Queen (2)"})
+	if got := p.FreeName("Queen"); got != "Queen (3)" {
+		t.Errorf("FreeName = %q, attendu Queen (3)", got)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Schéma des liens profonds, ex: groupie://artist/42
+const routeScheme = "groupie"
+
+// RouteKind identifie un type d'écran de l'application
+type RouteKind string
+
+// Écrans disponibles
+const (
+	RouteList     RouteKind = "list"
+	RouteArtist   RouteKind = "artist"
+	RouteMap      RouteKind = "map"
+	RouteCompare  RouteKind = "compare"
+	RouteStats    RouteKind = "stats"
+	RouteCalendar RouteKind = "calendar"
+)
+
+// Route décrit un écran et ses paramètres
+type Route struct {
+	Kind  RouteKind
+	ID    int    // Artiste affiché (artist, map)
+	IDs   []int  // Artistes comparés (compare)
+	Query string // Texte de recherche (list)
+	Place string // Lieu sur lequel centrer la carte (map, optionnel)
+}
+
+// String renvoie le lien profond de la route
+// Exemples : "groupie://artist/42", "groupie://list?q=queen", "groupie://compare/1,5"
+func (r Route) String() string {
+	switch r.Kind {
+	case RouteMap:
+		if r.Place != "" {
+			return fmt.Sprintf("%s://%s/%d?place=%s", routeScheme, r.Kind, r.ID, url.QueryEscape(r.Place))
+		}
+		return fmt.Sprintf("%s://%s/%d", routeScheme, r.Kind, r.ID)
+	case RouteArtist:
+		return fmt.Sprintf("%s://%s/%d", routeScheme, r.Kind, r.ID)
+	case RouteCompare:
+		ids := make([]string, len(r.IDs))
+		for i, id := range r.IDs {
+			ids[i] = strconv.Itoa(id)
+		}
+		return fmt.Sprintf("%s://%s/%s", routeScheme, r.Kind, strings.Join(ids, ","))
+	case RouteList:
+		if r.Query != "" {
+			return fmt.Sprintf("%s://%s?q=%s", routeScheme, r.Kind, url.QueryEscape(r.Query))
+		}
+	}
+	return fmt.Sprintf("%s://%s", routeScheme, r.Kind)
+}
+
+// ParseRoute lit un lien profond de la forme groupie://artist/42
+func ParseRoute(link string) (Route, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return Route{}, err
+	}
+	if u.Scheme != routeScheme {
+		return Route{}, fmt.Errorf("lien invalide %q : schéma %s:// attendu", link, routeScheme)
+	}
+
+	// Dans groupie://artist/42, "artist" est l'hôte et "/42" le chemin
+	route := Route{Kind: RouteKind(u.Host)}
+	param := strings.Trim(u.Path, "/")
+
+	switch route.Kind {
+	case RouteList:
+		route.Query = u.Query().Get("q")
+	case RouteStats, RouteCalendar:
+	case RouteArtist, RouteMap:
+		route.ID, err = strconv.Atoi(param)
+		if err != nil {
+			return Route{}, fmt.Errorf("lien invalide %q : identifiant attendu", link)
+		}
+		if route.Kind == RouteMap {
+			route.Place = u.Query().Get("place")
+		}
+	case RouteCompare:
+		for _, p := range strings.Split(param, ",") {
+			id, err := strconv.Atoi(p)
+			if err != nil {
+				return Route{}, fmt.Errorf("lien invalide %q : identifiants attendus", link)
+			}
+			route.IDs = append(route.IDs, id)
+		}
+	default:
+		return Route{}, fmt.Errorf("lien invalide %q : écran %q inconnu", link, u.Host)
+	}
+	return route, nil
+}
+
+// router affiche les écrans et garde l'historique de navigation
+type router struct {
+	back    []Route
+	current Route
+	forward []Route
+	views   map[RouteKind]func(Route) bool
+}
+
+func newRouter() *router {
+	return &router{views: map[RouteKind]func(Route) bool{}}
+}
+
+// Register associe un type d'écran à la fonction qui l'affiche
+// show renvoie false si la route ne peut pas être affichée (artiste inconnu...)
+func (r *router) Register(kind RouteKind, show func(Route) bool) {
+	r.views[kind] = show
+}
+
+// Current renvoie la route affichée
+func (r *router) Current() Route {
+	return r.current
+}
+
+// Navigate affiche une route et l'ajoute à l'historique
+// L'historique "suivant" est effacé, comme dans un navigateur
+func (r *router) Navigate(route Route) bool {
+	if !r.show(route) {
+		return false
+	}
+	if r.current.Kind != "" {
+		r.back = append(r.back, r.current)
+	}
+	r.current = route
+	r.forward = nil
+	return true
+}
+
+// Replace met à jour la route courante sans créer d'entrée d'historique
+// (ex: le texte de recherche de la liste)
+func (r *router) Replace(route Route) {
+	r.current = route
+}
+
+// Reload réaffiche la route courante, ex: après un changement de langue ou de données
+func (r *router) Reload() bool {
+	return r.show(r.current)
+}
+
+// Back revient à l'écran précédent
+func (r *router) Back() bool {
+	if len(r.back) == 0 {
+		return false
+	}
+	prev := r.back[len(r.back)-1]
+	if !r.show(prev) {
+		return false
+	}
+	r.back = r.back[:len(r.back)-1]
+	r.forward = append(r.forward, r.current)
+	r.current = prev
+	return true
+}
+
+// Forward revient à l'écran quitté avec Back
+func (r *router) Forward() bool {
+	if len(r.forward) == 0 {
+		return false
+	}
+	next := r.forward[len(r.forward)-1]
+	if !r.show(next) {
+		return false
+	}
+	r.forward = r.forward[:len(r.forward)-1]
+	r.back = append(r.back, r.current)
+	r.current = next
+	return true
+}
+
+// show affiche une route avec la vue enregistrée pour son type
+func (r *router) show(route Route) bool {
+	view, ok := r.views[route.Kind]
+	if !ok {
+		return false
+	}
+	return view(route)
+}
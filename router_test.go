@@ -0,0 +1,86 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRouteRoundTrip(t *testing.T) {
+	routes := []Route{
+		{Kind: RouteList},
+		{Kind: RouteList, Query: "pink floyd"},
+		{Kind: RouteArtist, ID: 42},
+		{Kind: RouteMap, ID: 3},
+		{Kind: RouteMap, ID: 3, Place: "Paris, France"},
+		{Kind: RouteCompare, IDs: []int{1, 5, 9}},
+		{Kind: RouteStats},
+		{Kind: RouteCalendar},
+	}
+	for _, want := range routes {
+		got, err := ParseRoute(want.String())
+		if err != nil {
+			t.Errorf("%s : %v", want, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s relu en %+v", want, got)
+		}
+	}
+}
+
+func TestParseRouteErrors(t *testing.T) {
+	for _, link := range []string{
+		"http://artist/1",
+		"groupie://artist/abc",
+		"groupie://compare/1,x",
+		"groupie://inconnu",
+	} {
+		if _, err := ParseRoute(link); err == nil {
+			t.Errorf("%s : erreur attendue", link)
+		}
+	}
+}
+
+func TestRouterHistory(t *testing.T) {
+	var shown []Route
+	r := newRouter()
+	for _, kind := range []RouteKind{RouteList, RouteArtist, RouteStats} {
+		r.Register(kind, func(route Route) bool {
+			shown = append(shown, route)
+			return route.ID != 999
+		})
+	}
+
+	r.Navigate(Route{Kind: RouteList})
+	r.Navigate(Route{Kind: RouteArtist, ID: 1})
+	r.Navigate(Route{Kind: RouteStats})
+	if r.Navigate(Route{Kind: RouteArtist, ID: 999}) {
+		t.Error("route refusée par l'écran acceptée")
+	}
+	if got := r.Current(); got.Kind != RouteStats {
+		t.Errorf("courante = %s, attendu stats", got)
+	}
+
+	if !r.Back() || r.Current().ID != 1 {
+		t.Errorf("après Back : %s", r.Current())
+	}
+	if !r.Forward() || r.Current().Kind != RouteStats {
+		t.Errorf("après Forward : %s", r.Current())
+	}
+	if r.Forward() {
+		t.Error("Forward sans historique suivant")
+	}
+
+	// Une nouvelle navigation efface l'historique suivant
+	r.Back()
+	r.Navigate(Route{Kind: RouteList, Query: "queen"})
+	if r.Forward() {
+		t.Error("historique suivant conservé après une navigation")
+	}
+
+	// Replace ne modifie pas l'historique
+	r.Replace(Route{Kind: RouteList, Query: "queen 2"})
+	if !r.Back() || r.Current().ID != 1 {
+		t.Errorf("après Replace puis Back : %s", r.Current())
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"net/url"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+
+	"groupie/geo"
+	api "groupie/models"
+)
+
+// Clés des préférences des paramètres
+const (
+	prefAPIURL       = "apiURL"
+	prefTheme        = "theme"
+	prefTileProvider = "tileProvider"
+	prefGeocoder     = "geocoder"
+	prefCacheSize    = "cacheSizeMB"
+	prefCacheTTL     = "cacheTTLDays"
+	prefHTTPTimeout  = "httpTimeoutSeconds"
+)
+
+// Thèmes proposés
+const (
+	themeSystem = "system"
+	themeLight  = "light"
+	themeDark   = "dark"
+)
+
+// Settings regroupe les paramètres de l'application, sauvegardés dans les préférences
+type Settings struct {
+	APIURL       string
+	Theme        string // themeSystem, themeLight ou themeDark
+	Language     string // "" : langue du système
+	TileProvider string // Nom dans tileProviders
+	Geocoder     string // Nom dans geo.Services
+	CacheSizeMB  int    // Taille maximale du cache des photos
+	CacheTTLDays int    // Durée de vie des photos en cache
+	HTTPTimeout  int    // Délai maximal d'une requête, en secondes
+	Sort         api.SortOrder
+}
+
+// loadSettings relit les paramètres, avec leurs valeurs par défaut
+func loadSettings(prefs fyne.Preferences) Settings {
+	s := Settings{
+		APIURL:       prefs.StringWithFallback(prefAPIURL, api.DefaultBaseURL),
+		Theme:        prefs.StringWithFallback(prefTheme, themeSystem),
+		Language:     prefs.String(prefLanguage),
+		TileProvider: prefs.StringWithFallback(prefTileProvider, tileProviders[0].Name),
+		Geocoder:     prefs.StringWithFallback(prefGeocoder, geo.Nominatim),
+		CacheSizeMB:  prefs.IntWithFallback(prefCacheSize, 200),
+		CacheTTLDays: prefs.IntWithFallback(prefCacheTTL, 30),
+		HTTPTimeout:  prefs.IntWithFallback(prefHTTPTimeout, int(api.DefaultTimeout/time.Second)),
+	}
+	order, ok := api.ParseSortOrder(prefs.String(prefSortOrder))
+	if !ok {
+		order = api.SortNameAsc
+	}
+	s.Sort = order
+	return s
+}
+
+// save sauvegarde les paramètres dans les préférences
+func (s Settings) save(prefs fyne.Preferences) {
+	prefs.SetString(prefAPIURL, s.APIURL)
+	prefs.SetString(prefTheme, s.Theme)
+	prefs.SetString(prefLanguage, s.Language)
+	prefs.SetString(prefTileProvider, s.TileProvider)
+	prefs.SetString(prefGeocoder, s.Geocoder)
+	prefs.SetInt(prefCacheSize, s.CacheSizeMB)
+	prefs.SetInt(prefCacheTTL, s.CacheTTLDays)
+	prefs.SetInt(prefHTTPTimeout, s.HTTPTimeout)
+	prefs.SetString(prefSortOrder, string(s.Sort))
+}
+
+// validAPIURL vérifie qu'une URL d'API est une adresse http(s) complète
+func validAPIURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// applySettings applique les paramètres qui ne dépendent pas de la fenêtre :
+// délais, cartes, cache des photos, thème et langue
+// L'URL de l'API est appliquée à part : en changer demande de recharger les artistes
+func applySettings(fyneApp fyne.App, s Settings) {
+	timeout := time.Duration(s.HTTPTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = api.DefaultTimeout
+	}
+	api.SetTimeout(timeout)
+	client.SetTimeout(timeout)
+	geo.SetTimeout(timeout)
+	imageClient.SetTimeout(timeout)
+
+	setTileProvider(s.TileProvider)
+	geo.SetService(s.Geocoder)
+
+	images.SetLimits(int64(s.CacheSizeMB)<<20, time.Duration(s.CacheTTLDays)*24*time.Hour)
+	go images.Prune()
+
+	fyneApp.Settings().SetTheme(themeFor(s.Theme))
+	setLanguage(s.Language)
+}
+
+// themeFor renvoie le thème correspondant au choix des paramètres
+func themeFor(name string) fyne.Theme {
+	switch name {
+	case themeLight:
+		return groupieTheme{forced: true, variant: theme.VariantLight}
+	case themeDark:
+		return groupieTheme{forced: true, variant: theme.VariantDark}
+	}
+	return groupieTheme{}
+}
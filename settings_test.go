@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+
+	"groupie/geo"
+	api "groupie/models"
+)
+
+func TestSettingsPersist(t *testing.T) {
+	prefs := test.NewTempApp(t).Preferences()
+
+	// Sans préférences : valeurs par défaut
+	s := loadSettings(prefs)
+	if s.APIURL != api.DefaultBaseURL || s.Theme != themeSystem || s.HTTPTimeout != 10 || s.Sort != api.SortNameAsc {
+		t.Errorf("par défaut : %+v", s)
+	}
+
+	s.APIURL = "http://localhost:8080/api"
+	s.Theme = themeDark
+	s.Language = "en"
+	s.Geocoder = geo.Photon
+	s.CacheSizeMB = 50
+	s.Sort = api.SortCreation
+	s.save(prefs)
+	if got := loadSettings(prefs); got != s {
+		t.Errorf("relus : %+v, attendu %+v", got, s)
+	}
+}
+
+func TestSettingsForm(t *testing.T) {
+	test.NewTempApp(t)
+	setLanguage("fr")
+	s := Settings{
+		APIURL: api.DefaultBaseURL, Theme: themeLight, Language: "en",
+		TileProvider: "OpenTopoMap", Geocoder: geo.Nominatim,
+		CacheSizeMB: 200, CacheTTLDays: 30, HTTPTimeout: 10, Sort: api.SortNameDesc,
+	}
+
+	// Le formulaire affiche les paramètres et les renvoie sans perte
+	f := newSettingsForm(s)
+	if f.theme.Selected != "Clair" || f.language.Selected != "English" {
+		t.Errorf("thème %q, langue %q", f.theme.Selected, f.language.Selected)
+	}
+	if got := f.settings(); got != s {
+		t.Errorf("formulaire : %+v, attendu %+v", got, s)
+	}
+
+	// Langue du système : première option
+	f.language.SetSelectedIndex(0)
+	if got := f.settings(); got.Language != "" {
+		t.Errorf("langue = %q, attendu celle du système", got.Language)
+	}
+
+	if f.apiURL.Validator("ftp://exemple.fr") == nil || f.apiURL.Validator("http://") == nil {
+		t.Error("URL invalide acceptée")
+	}
+	if f.timeout.Validator("0") == nil || f.timeout.Validator("dix") == nil || f.timeout.Validator("30") != nil {
+		t.Error("validation du délai")
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+
+	"groupie/geo"
+	api "groupie/models"
+)
+
+// settingsForm contient les champs de la fenêtre des paramètres
+type settingsForm struct {
+	apiURL    *widget.Entry
+	theme     *widget.Select
+	language  *widget.Select
+	tiles     *widget.Select
+	geocoder  *widget.Select
+	cacheSize *widget.Entry
+	cacheTTL  *widget.Entry
+	timeout   *widget.Entry
+	sort      *widget.Select
+}
+
+// Libellés des thèmes, dans l'ordre du sélecteur (clés des catalogues)
+var themeLabels = []struct {
+	Theme string
+	Label string
+}{
+	{themeSystem, "Système"},
+	{themeLight, "Clair"},
+	{themeDark, "Sombre"},
+}
+
+// intValidator accepte un nombre entier entre min et max
+func intValidator(min, max int) fyne.StringValidator {
+	return func(s string) error {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < min || n > max {
+			return fmt.Errorf(lang.L("nombre entre %d et %d attendu"), min, max)
+		}
+		return nil
+	}
+}
+
+// newSettingsForm crée les champs remplis avec les paramètres s
+func newSettingsForm(s Settings) *settingsForm {
+	f := &settingsForm{}
+
+	f.apiURL = widget.NewEntry()
+	f.apiURL.SetText(s.APIURL)
+	f.apiURL.Validator = func(text string) error {
+		if !validAPIURL(text) {
+			return errors.New(lang.L("adresse http(s) attendue"))
+		}
+		return nil
+	}
+
+	f.theme = widget.NewSelect(nil, nil)
+	for _, t := range themeLabels {
+		f.theme.Options = append(f.theme.Options, lang.L(t.Label))
+		if t.Theme == s.Theme {
+			f.theme.SetSelected(lang.L(t.Label))
+		}
+	}
+
+	f.language = widget.NewSelect([]string{lang.L("Système")}, nil)
+	f.language.SetSelectedIndex(0)
+	for _, code := range languages {
+		f.language.Options = append(f.language.Options, languageNames[code])
+		if code == s.Language {
+			f.language.SetSelected(languageNames[code])
+		}
+	}
+
+	var tiles []string
+	for _, p := range tileProviders {
+		tiles = append(tiles, p.Name)
+	}
+	f.tiles = widget.NewSelect(tiles, nil)
+	f.tiles.SetSelected(s.TileProvider)
+	f.geocoder = widget.NewSelect(geo.Services, nil)
+	f.geocoder.SetSelected(s.Geocoder)
+
+	f.cacheSize = widget.NewEntry()
+	f.cacheSize.SetText(strconv.Itoa(s.CacheSizeMB))
+	f.cacheSize.Validator = intValidator(1, 100000)
+	f.cacheTTL = widget.NewEntry()
+	f.cacheTTL.SetText(strconv.Itoa(s.CacheTTLDays))
+	f.cacheTTL.Validator = intValidator(1, 3650)
+	f.timeout = widget.NewEntry()
+	f.timeout.SetText(strconv.Itoa(s.HTTPTimeout))
+	f.timeout.Validator = intValidator(1, 300)
+
+	var orders []string
+	for _, o := range api.SortOrders {
+		orders = append(orders, sortLabel(o))
+	}
+	f.sort = widget.NewSelect(orders, nil)
+	f.sort.SetSelected(sortLabel(s.Sort))
+	return f
+}
+
+// items renvoie les lignes du formulaire
+func (f *settingsForm) items() []*widget.FormItem {
+	return []*widget.FormItem{
+		widget.NewFormItem(lang.L("URL de l'API"), f.apiURL),
+		widget.NewFormItem(lang.L("Thème"), f.theme),
+		widget.NewFormItem(lang.L("Langue"), f.language),
+		widget.NewFormItem(lang.L("Fond de carte"), f.tiles),
+		widget.NewFormItem(lang.L("Géocodeur"), f.geocoder),
+		widget.NewFormItem(lang.L("Cache des photos (Mo)"), f.cacheSize),
+		widget.NewFormItem(lang.L("Durée du cache (jours)"), f.cacheTTL),
+		widget.NewFormItem(lang.L("Délai des requêtes (s)"), f.timeout),
+		widget.NewFormItem(lang.L("Tri par défaut"), f.sort),
+	}
+}
+
+// settings renvoie les paramètres saisis
+func (f *settingsForm) settings() Settings {
+	s := Settings{
+		APIURL:       f.apiURL.Text,
+		Theme:        themeLabels[max(f.theme.SelectedIndex(), 0)].Theme,
+		TileProvider: f.tiles.Selected,
+		Geocoder:     f.geocoder.Selected,
+		Sort:         sortOrderFromLabel(f.sort.Selected),
+	}
+	if i := f.language.SelectedIndex(); i > 0 {
+		s.Language = languages[i-1]
+	}
+	s.CacheSizeMB, _ = strconv.Atoi(f.cacheSize.Text)
+	s.CacheTTLDays, _ = strconv.Atoi(f.cacheTTL.Text)
+	s.HTTPTimeout, _ = strconv.Atoi(f.timeout.Text)
+	return s
+}
+
+// showSettings affiche la fenêtre des paramètres sur win, remplie avec ceux des préférences
+// onSave reçoit les paramètres d'origine et ceux saisis, qu'il doit sauvegarder et appliquer
+func showSettings(win fyne.Window, prefs fyne.Preferences, onSave func(old, s Settings)) {
+	old := loadSettings(prefs)
+	form := newSettingsForm(old)
+	d := dialog.NewForm(lang.L("Paramètres"), lang.L("Enregistrer"), lang.L("Annuler"), form.items(), func(ok bool) {
+		if ok {
+			onSave(old, form.settings())
+		}
+	}, win)
+	d.Resize(fyne.NewSize(550, 0))
+	d.Show()
+}
+
+// openSettings affiche la fenêtre des paramètres ; ils sont sauvegardés et appliqués à la validation
+func (g *App) openSettings() {
+	showSettings(g.win, g.fyne.Preferences(), g.updateSettings)
+}
+
+// updateSettings sauvegarde et applique des paramètres modifiés sans redémarrer
+// Une nouvelle URL d'API n'est sauvegardée qu'une fois les artistes chargés depuis celle-ci
+func (g *App) updateSettings(old, s Settings) {
+	prefs := g.fyne.Preferences()
+	saved := s
+	saved.APIURL = old.APIURL
+	saved.save(prefs)
+
+	applySettings(g.fyne, s)
+	if s.Sort != old.Sort {
+		g.store.Sort.Set(string(s.Sort))
+	}
+	switch {
+	case s.APIURL != old.APIURL:
+		// Les vues sont reconstruites avec les nouvelles données, dans la nouvelle langue
+		g.reload(s.APIURL, func(ok bool) {
+			if ok {
+				prefs.SetString(prefAPIURL, s.APIURL)
+			} else if s.Language != old.Language {
+				g.rebuild()
+			}
+		})
+	case s.Language != old.Language:
+		g.rebuild()
+	}
+}
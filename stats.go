@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	api "groupie/models"
+)
+
+// statChart identifie un graphique du tableau de bord
+type statChart int
+
+const (
+	chartDecades statChart = iota
+	chartAlbumGaps
+	chartMembers
+	chartCities
+	chartCountries
+	chartYears
+)
+
+// Largeur réservée aux libellés des barres
+const barLabelWidth = 140
+
+// bar est une barre horizontale cliquable, proportionnelle à ratio (0 à 1)
+type bar struct {
+	widget.BaseWidget
+	ratio    float32
+	onTapped func()
+}
+
+func newBar(ratio float32, onTapped func()) *bar {
+	b := &bar{ratio: ratio, onTapped: onTapped}
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+// Tapped applique le filtre correspondant à la barre
+func (b *bar) Tapped(*fyne.PointEvent) {
+	if b.onTapped != nil {
+		b.onTapped()
+	}
+}
+
+// Cursor affiche une main au survol pour indiquer que la barre est cliquable
+func (b *bar) Cursor() desktop.Cursor {
+	return desktop.PointerCursor
+}
+
+func (b *bar) CreateRenderer() fyne.WidgetRenderer {
+	rect := canvas.NewRectangle(theme.Color(colorNameChart))
+	return &barRenderer{bar: b, rect: rect}
+}
+
+type barRenderer struct {
+	bar  *bar
+	rect *canvas.Rectangle
+}
+
+func (r *barRenderer) Layout(size fyne.Size) {
+	// Une barre non nulle reste visible même très courte
+	width := size.Width * r.bar.ratio
+	if r.bar.ratio > 0 && width < 2 {
+		width = 2
+	}
+	r.rect.Resize(fyne.NewSize(width, size.Height))
+	r.rect.Move(fyne.NewPos(0, 0))
+}
+
+func (r *barRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(60, theme.Size(theme.SizeNameText)+theme.Size(theme.SizeNameInnerPadding))
+}
+
+func (r *barRenderer) Refresh() {
+	r.rect.FillColor = theme.Color(colorNameChart)
+	r.rect.Refresh()
+	r.Layout(r.bar.Size())
+}
+
+func (r *barRenderer) Objects() []fyne.CanvasObject { return []fyne.CanvasObject{r.rect} }
+func (r *barRenderer) Destroy()                     {}
+
+// newBarChart crée un graphique en barres horizontales dans une card
+// onTapped est appelé avec la barre cliquée
+func newBarChart(title string, buckets []api.Bucket, onTapped func(api.Bucket)) fyne.CanvasObject {
+	maxValue := 0
+	for _, b := range buckets {
+		if b.Value > maxValue {
+			maxValue = b.Value
+		}
+	}
+
+	rows := container.NewVBox(widget.NewLabelWithStyle(title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	if len(buckets) == 0 {
+		rows.Add(widget.NewLabel(lang.L("Aucune donnée")))
+	}
+	for _, b := range buckets {
+		b := b
+		ratio := float32(0)
+		if maxValue > 0 {
+			ratio = float32(b.Value) / float32(maxValue)
+		}
+
+		label := widget.NewLabel(b.Label)
+		label.Truncation = fyne.TextTruncateEllipsis
+		labelBox := container.NewGridWrap(fyne.NewSize(barLabelWidth, label.MinSize().Height), label)
+
+		rows.Add(container.NewBorder(
+			nil, nil, labelBox, widget.NewLabel(fmt.Sprint(b.Value)),
+			newBar(ratio, func() { onTapped(b) }),
+		))
+	}
+	return createCard(rows)
+}
+
+// translateBuckets traduit les libellés des barres, calculés en français par le paquet models
+// Les lieux, pays et années sont gardés tels quels
+func translateBuckets(kind statChart, buckets []api.Bucket) []api.Bucket {
+	var label func(int) string
+	switch kind {
+	case chartDecades:
+		label = func(k int) string { return fmt.Sprintf(lang.L("Années %d"), k) }
+	case chartAlbumGaps:
+		label = func(k int) string { return plural("%d an(s)", k) }
+	case chartMembers:
+		label = func(k int) string { return plural("%d membre(s)", k) }
+	default:
+		return buckets
+	}
+	translated := make([]api.Bucket, len(buckets))
+	for i, b := range buckets {
+		b.Label = label(b.Key)
+		translated[i] = b
+	}
+	return translated
+}
+
+// newStatsView crée le tableau de bord des statistiques
+// onSelect reçoit le graphique et la barre cliquée pour filtrer la liste
+func newStatsView(stats api.Stats, onSelect func(statChart, api.Bucket), onBack func()) fyne.CanvasObject {
+	chart := func(kind statChart, title string, buckets []api.Bucket) fyne.CanvasObject {
+		return newBarChart(title, translateBuckets(kind, buckets), func(b api.Bucket) { onSelect(kind, b) })
+	}
+
+	charts := container.NewGridWithColumns(2,
+		chart(chartDecades, lang.L("Artistes créés par décennie"), stats.Decades),
+		chart(chartAlbumGaps, lang.L("Années entre création et premier album"), stats.AlbumGaps),
+		chart(chartMembers, lang.L("Nombre de membres"), stats.Members),
+		chart(chartYears, lang.L("Concerts par année"), stats.ConcertsPerYear),
+		chart(chartCities, lang.L("Top 20 des lieux de concert"), stats.TopCities),
+		chart(chartCountries, lang.L("Top 20 des pays"), stats.TopCountries),
+	)
+
+	backBtn := widget.NewButton(lang.L("Retour (Échap)"), onBack)
+	title := widget.NewLabelWithStyle(lang.L("Statistiques"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	hint := widget.NewLabelWithStyle(lang.L("Cliquez sur une barre pour filtrer la liste"), fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+
+	return container.NewBorder(
+		container.NewVBox(container.NewBorder(nil, nil, backBtn, nil, title), hint),
+		nil, nil, nil,
+		container.NewPadded(container.NewVScroll(charts)),
+	)
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+
+	api "groupie/models"
+)
+
+// searchFields liste les champs de recherche proposés dans le menu des filtres
+var searchFields = []struct {
+	Field string // Champ de recherche (api.FieldName...)
+	Label string
+}{
+	{api.FieldName, "Artistes"},
+	{api.FieldMember, "Membres"},
+	{api.FieldLocation, "Lieux"},
+	{api.FieldAlbum, "Premier album"},
+	{api.FieldCreated, "Création"},
+}
+
+// FilterState est l'état de la recherche à un instant donné : texte, champs, favoris,
+// période de concerts et tri
+type FilterState struct {
+	Query   string          `json:"query,omitempty"`
+	Fields  map[string]bool `json:"fields,omitempty"` // Champs cochés ; aucun = tous
+	FavOnly bool            `json:"favOnly,omitempty"`
+
+	// Période de concerts : "qui a joué à Paris en 2019 ?"
+	// Les bornes sont gardées telles que saisies (AAAA ou JJ-MM-AAAA)
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+	Place string `json:"place,omitempty"`
+
+	Sort api.SortOrder `json:"sort,omitempty"`
+}
+
+// Results est le résultat d'une recherche
+type Results struct {
+	Artists  []api.Artist
+	Concerts map[int][]api.Concert // Concerts de la période par artiste, vide sans filtre de période
+}
+
+// Store est l'état observable de la recherche
+// Les filtres sont des bindings Fyne liés aux widgets ; chaque changement recalcule
+// les résultats, le compteur et les compteurs par champ, que les vues observent à leur tour
+type Store struct {
+	artists   []api.Artist
+	concerts  map[int][]api.Concert
+	favs      *favorites
+	locations map[int]string // Lieux de chaque artiste, en minuscules, pour la recherche
+
+	statFilter func(api.Artist) bool // Filtre appliqué depuis le tableau de statistiques
+
+	applying bool         // Apply en cours : une seule recherche à la fin du lot
+	searched *FilterState // Filtres de la dernière recherche
+
+	// Filtres
+	Query   binding.String
+	Fields  map[string]binding.Bool // Par champ de searchFields
+	FavOnly binding.Bool
+	From    binding.String
+	To      binding.String
+	Place   binding.String
+	Sort    binding.String // api.SortOrder
+
+	// Résultats
+	Results binding.Item[Results]
+	Count   binding.Int
+	Facets  map[string]binding.Int // Artistes trouvés par chaque champ seul
+}
+
+// newStore crée l'état de la recherche sur les artistes et l'index de leurs concerts
+func newStore(artists []api.Artist, concerts map[int][]api.Concert, favs *favorites, order api.SortOrder) *Store {
+	s := &Store{
+		artists:   artists,
+		concerts:  concerts,
+		favs:      favs,
+		locations: map[int]string{},
+		Query:     binding.NewString(),
+		Fields:    map[string]binding.Bool{},
+		FavOnly:   binding.NewBool(),
+		From:      binding.NewString(),
+		To:        binding.NewString(),
+		Place:     binding.NewString(),
+		Sort:      binding.NewString(),
+		// Chaque recherche produit de nouveaux résultats, même de longueur identique
+		Results: binding.NewItem(func(Results, Results) bool { return false }),
+		Count:   binding.NewInt(),
+		Facets:  map[string]binding.Int{},
+	}
+	s.Sort.Set(string(order))
+
+	inputs := []binding.DataItem{s.Query, s.FavOnly, s.From, s.To, s.Place, s.Sort}
+	for _, f := range searchFields {
+		s.Fields[f.Field] = binding.NewBool()
+		s.Facets[f.Field] = binding.NewInt()
+		inputs = append(inputs, s.Fields[f.Field])
+	}
+
+	s.loadLocations()
+	s.update()
+	listener := binding.NewDataListener(s.filtersChanged)
+	for _, in := range inputs {
+		in.AddListener(listener)
+	}
+	return s
+}
+
+// loadLocations prépare les lieux de chaque artiste pour la recherche
+// Ils viennent de l'index des concerts ; ceux des artistes absents de l'index sont demandés
+// à l'API en arrière-plan, une seule fois : un lieu indisponible reste vide jusqu'au rechargement
+func (s *Store) loadLocations() {
+	var missing []api.Artist
+	for _, a := range s.artists {
+		concerts, ok := s.concerts[a.ID]
+		if !ok {
+			missing = append(missing, a)
+			continue
+		}
+		var b strings.Builder
+		for _, c := range concerts {
+			b.WriteString(c.Place() + "\n" + c.Location + "\n")
+		}
+		s.locations[a.ID] = strings.ToLower(b.String())
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	task := newTask(func() (map[int]string, error) {
+		locations := make(map[int]string, len(missing))
+		for _, a := range missing {
+			list, _ := api.FetchLocationList(a.LocationsURL)
+			locations[a.ID] = strings.ToLower(strings.Join(list, "\n"))
+		}
+		return locations, nil
+	})
+	task.OnChange(func(t *Task[map[int]string]) {
+		if t.State() != TaskDone {
+			return
+		}
+		for id, loc := range t.Value() {
+			s.locations[id] = loc
+		}
+		s.update()
+	})
+}
+
+// State renvoie l'état des filtres
+func (s *Store) State() FilterState {
+	st := FilterState{Fields: map[string]bool{}}
+	st.Query, _ = s.Query.Get()
+	st.FavOnly, _ = s.FavOnly.Get()
+	st.From, _ = s.From.Get()
+	st.To, _ = s.To.Get()
+	st.Place, _ = s.Place.Get()
+	sort, _ := s.Sort.Get()
+	st.Sort = api.SortOrder(sort)
+	for field, b := range s.Fields {
+		if on, _ := b.Get(); on {
+			st.Fields[field] = true
+		}
+	}
+	return st
+}
+
+// Apply remplace l'état des filtres ; les vues liées suivent
+// La recherche n'est faite qu'une fois, avec tous les filtres
+func (s *Store) Apply(st FilterState) {
+	s.applying = true
+	defer func() {
+		s.applying = false
+		s.update()
+	}()
+	s.Query.Set(st.Query)
+	s.FavOnly.Set(st.FavOnly)
+	s.From.Set(st.From)
+	s.To.Set(st.To)
+	s.Place.Set(st.Place)
+	if _, ok := api.ParseSortOrder(string(st.Sort)); ok {
+		s.Sort.Set(string(st.Sort))
+	}
+	for field, b := range s.Fields {
+		b.Set(st.Fields[field])
+	}
+}
+
+// SetStatFilter applique un filtre venu du tableau de statistiques (nil pour le retirer)
+func (s *Store) SetStatFilter(filter func(api.Artist) bool) {
+	s.statFilter = filter
+	s.update()
+}
+
+// Refresh recalcule les résultats après un changement extérieur aux filtres (favoris...)
+func (s *Store) Refresh() {
+	s.update()
+}
+
+// Current renvoie les derniers résultats
+func (s *Store) Current() Results {
+	r, _ := s.Results.Get()
+	return r
+}
+
+// filtersChanged relance la recherche quand un filtre change
+// Les bindings préviennent après coup, un par un : les filtres déjà cherchés sont ignorés
+func (s *Store) filtersChanged() {
+	if s.applying {
+		return
+	}
+	if st := s.State(); s.searched != nil && reflect.DeepEqual(st, *s.searched) {
+		return
+	}
+	s.update()
+}
+
+// update recalcule les résultats à partir des filtres
+func (s *Store) update() {
+	st := s.State()
+	s.searched = &st
+	text := strings.ToLower(st.Query)
+
+	// Période de concerts (une saisie invalide est ignorée, et signalée par le menu des filtres)
+	from, errFrom := api.ParseDateBound(st.From, false)
+	to, errTo := api.ParseDateBound(st.To, true)
+	if errFrom != nil {
+		from = time.Time{}
+	}
+	if errTo != nil {
+		to = time.Time{}
+	}
+	period := !from.IsZero() || !to.IsZero() || strings.TrimSpace(st.Place) != ""
+	allFields := len(st.Fields) == 0
+
+	res := Results{Concerts: map[int][]api.Concert{}}
+	facets := map[string]int{}
+	for _, a := range s.artists {
+
+		// FAVORIS
+		if st.FavOnly && !s.favs.Has(a.ID) {
+			continue
+		}
+
+		// STATISTIQUES
+		if s.statFilter != nil && !s.statFilter(a) {
+			continue
+		}
+
+		// PERIODE DE CONCERTS
+		var matches []api.Concert
+		if period {
+			matches = api.PlayedBetween(s.concerts[a.ID], from, to, st.Place)
+			if len(matches) == 0 {
+				continue
+			}
+		}
+
+		// CHAMPS : chaque champ compte ses artistes, les champs cochés font le résultat
+		match := false
+		for _, f := range searchFields {
+			if !s.fieldMatches(f.Field, a, text) {
+				continue
+			}
+			facets[f.Field]++
+			if allFields || st.Fields[f.Field] {
+				match = true
+			}
+		}
+		if !match {
+			continue
+		}
+
+		res.Artists = append(res.Artists, a)
+		if period {
+			res.Concerts[a.ID] = matches
+		}
+	}
+
+	// La recherche de l'interface est un seul texte : il compte comme un seul terme
+	api.SortArtists(res.Artists, st.Sort, s.concerts, []string{text})
+
+	s.Results.Set(res)
+	s.Count.Set(len(res.Artists))
+	for field, b := range s.Facets {
+		b.Set(facets[field])
+	}
+}
+
+// fieldMatches indique si un champ de l'artiste contient le texte (en minuscules)
+func (s *Store) fieldMatches(field string, a api.Artist, text string) bool {
+	contains := func(value string) bool { return strings.Contains(strings.ToLower(value), text) }
+	switch field {
+	case api.FieldName:
+		return contains(a.Name)
+	case api.FieldMember:
+		for _, m := range a.Members {
+			if contains(m) {
+				return true
+			}
+		}
+	case api.FieldLocation:
+		return strings.Contains(s.locations[a.ID], text)
+	case api.FieldAlbum:
+		return contains(a.FirstAlbum)
+	case api.FieldCreated:
+		return contains(fmt.Sprint(a.CreationDate))
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+
+	api "groupie/models"
+)
+
+func TestApplySearchesOnce(t *testing.T) {
+	artists := []api.Artist{{ID: 1, Name: "Queen"}, {ID: 2, Name: "SOJA"}}
+	s := newStore(artists, map[int][]api.Concert{1: nil, 2: nil}, loadFavorites(test.NewTempApp(t).Preferences()), api.SortNameAsc)
+
+	searches := 0
+	s.Results.AddListener(binding.NewDataListener(func() { searches++ }))
+	searches = 0
+	s.Apply(FilterState{
+		Query:  "queen",
+		Fields: map[string]bool{api.FieldName: true, api.FieldMember: true},
+		From:   "1970",
+		To:     "2020",
+		Place:  "london",
+		Sort:   api.SortNameDesc,
+	})
+	runUI()
+	if searches != 1 {
+		t.Errorf("%d recherches pour un Apply, attendu 1", searches)
+	}
+}
+
+func TestLocationsLoadInBackground(t *testing.T) {
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/locations/2" {
+			http.Error(w, "indisponible", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"id":1,"locations":["paris-france"]}`))
+	}))
+	defer ts.Close()
+
+	// Aucun des deux artistes n'est dans l'index des concerts
+	artists := []api.Artist{
+		{ID: 1, Name: "Queen", LocationsURL: ts.URL + "/locations/1"},
+		{ID: 2, Name: "SOJA", LocationsURL: ts.URL + "/locations/2"},
+	}
+	s := newStore(artists, map[int][]api.Concert{}, loadFavorites(test.NewTempApp(t).Preferences()), api.SortNameAsc)
+	s.Fields[api.FieldLocation].Set(true)
+	s.Query.Set("paris")
+
+	waitFor(t, "lieux chargés", func() bool { return len(s.Current().Artists) == 1 })
+	if got := s.Current().Artists[0].Name; got != "Queen" {
+		t.Errorf("artiste trouvé à Paris = %s", got)
+	}
+
+	// Un lieu indisponible n'est pas redemandé à chaque recherche
+	s.Query.Set("france")
+	s.Query.Set("lyon")
+	runUI()
+	if n := requests.Load(); n != 2 {
+		t.Errorf("%d requêtes, attendu une par artiste", n)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// TaskState est l'état d'une tâche de fond
+type TaskState int
+
+const (
+	TaskLoading TaskState = iota // Travail en cours
+	TaskDone                     // Résultat disponible
+	TaskFailed                   // Le travail a renvoyé une erreur
+)
+
+// runOnUI exécute fn sur le thread UI sans attendre
+// Le pilote de test de Fyne exécute fyne.Do sur la goroutine appelante : les tests la remplacent
+// par une file exécutée sur leur propre goroutine (voir waitFor)
+var runOnUI = fyne.Do
+
+// Task exécute un travail en arrière-plan (requête, géocodage, décodage d'image...)
+// et applique son résultat sur le thread UI, comme l'exige Fyne depuis la 2.6
+// Son état et ses observateurs ne sont utilisés que depuis le thread UI
+type Task[T any] struct {
+	work      func() (T, error)
+	run       int // Numéro de l'exécution en cours : les résultats d'une exécution relancée sont ignorés
+	state     TaskState
+	value     T
+	err       error
+	listeners []func(*Task[T])
+}
+
+// newTask lance work en arrière-plan
+func newTask[T any](work func() (T, error)) *Task[T] {
+	t := &Task[T]{work: work}
+	t.Start()
+	return t
+}
+
+// Start (re)lance le travail ; les observateurs voient d'abord l'état TaskLoading
+func (t *Task[T]) Start() {
+	t.run++
+	run := t.run
+	t.state, t.err = TaskLoading, nil
+	t.notify()
+
+	go func() {
+		value, err := t.work()
+		runOnUI(func() {
+			if run != t.run {
+				return
+			}
+			t.value, t.err = value, err
+			if err != nil {
+				t.state = TaskFailed
+			} else {
+				t.state = TaskDone
+			}
+			t.notify()
+		})
+	}()
+}
+
+// OnChange ajoute un observateur, appelé tout de suite puis à chaque changement d'état
+func (t *Task[T]) OnChange(fn func(*Task[T])) {
+	t.listeners = append(t.listeners, fn)
+	fn(t)
+}
+
+func (t *Task[T]) notify() {
+	for _, fn := range t.listeners {
+		fn(t)
+	}
+}
+
+// State renvoie l'état de la tâche
+func (t *Task[T]) State() TaskState { return t.state }
+
+// Value renvoie le résultat, valable dans l'état TaskDone
+func (t *Task[T]) Value() T { return t.value }
+
+// Err renvoie l'erreur, valable dans l'état TaskFailed
+func (t *Task[T]) Err() error { return t.err }
+
+// newTaskView affiche une tâche : indicateur de chargement avec le texte loading,
+// puis le contenu créé par render, ou l'erreur avec un bouton pour réessayer
+func newTaskView[T any](task *Task[T], loading string, render func(T) fyne.CanvasObject) *fyne.Container {
+	holder := container.NewStack()
+	var spinner *widget.Activity
+	task.OnChange(func(t *Task[T]) {
+		// L'animation du chargement précédent est arrêtée
+		if spinner != nil {
+			spinner.Stop()
+			spinner = nil
+		}
+		var content fyne.CanvasObject
+		switch t.State() {
+		case TaskLoading:
+			spinner = widget.NewActivity()
+			spinner.Start()
+			content = container.NewHBox(spinner, widget.NewLabelWithStyle(loading, fyne.TextAlignLeading, fyne.TextStyle{Italic: true}))
+		case TaskFailed:
+			message := widget.NewLabel(fmt.Sprintf(lang.L("Erreur : %s"), errorText(t.Err())))
+			message.Wrapping = fyne.TextWrapWord
+			retry := widget.NewButtonWithIcon(lang.L("Réessayer"), theme.ViewRefreshIcon(), t.Start)
+			content = container.NewBorder(nil, nil, widget.NewIcon(theme.ErrorIcon()), retry, message)
+		case TaskDone:
+			content = render(t.Value())
+		}
+		holder.Objects = []fyne.CanvasObject{content}
+		holder.Refresh()
+	})
+	return holder
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+// uiQueue remplace le thread UI pendant les tests : les résultats des tâches de fond
+// y attendent que la goroutine du test les applique, sans accès concurrent aux widgets
+var uiQueue struct {
+	sync.Mutex
+	fns []func()
+}
+
+func TestMain(m *testing.M) {
+	runOnUI = func(fn func()) {
+		uiQueue.Lock()
+		uiQueue.fns = append(uiQueue.fns, fn)
+		uiQueue.Unlock()
+	}
+	os.Exit(m.Run())
+}
+
+// runUI applique sur la goroutine du test les fonctions en attente du thread UI
+func runUI() {
+	for {
+		uiQueue.Lock()
+		fns := uiQueue.fns
+		uiQueue.fns = nil
+		uiQueue.Unlock()
+		if len(fns) == 0 {
+			return
+		}
+		for _, fn := range fns {
+			fn()
+		}
+	}
+}
+
+// waitFor attend qu'une condition soit vraie, ou échoue après une seconde
+// Les résultats des tâches de fond sont appliqués avant chaque vérification
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for runUI(); !cond(); runUI() {
+		if time.Now().After(deadline) {
+			t.Fatalf("délai dépassé : %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestTaskStates(t *testing.T) {
+	test.NewTempApp(t)
+	release := make(chan struct{})
+	task := newTask(func() (int, error) {
+		<-release
+		return 42, nil
+	})
+
+	var states []TaskState
+	task.OnChange(func(task *Task[int]) { states = append(states, task.State()) })
+	if task.State() != TaskLoading {
+		t.Fatalf("état initial = %v, attendu TaskLoading", task.State())
+	}
+
+	close(release)
+	waitFor(t, "résultat de la tâche", func() bool { return task.State() == TaskDone })
+	if task.Value() != 42 || task.Err() != nil {
+		t.Errorf("résultat = %d, %v", task.Value(), task.Err())
+	}
+	if len(states) != 2 || states[1] != TaskDone {
+		t.Errorf("états observés = %v", states)
+	}
+}
+
+func TestTaskIgnoresStaleRun(t *testing.T) {
+	test.NewTempApp(t)
+	var runs atomic.Int32
+	first := make(chan struct{})
+	task := newTask(func() (int, error) {
+		if runs.Add(1) == 1 {
+			<-first
+			return 1, nil
+		}
+		return 2, nil
+	})
+
+	waitFor(t, "premier lancement", func() bool { return runs.Load() == 1 })
+	task.Start()
+	waitFor(t, "second résultat", func() bool { return task.State() == TaskDone })
+
+	// Le premier lancement se termine après le second : son résultat est ignoré
+	close(first)
+	time.Sleep(20 * time.Millisecond)
+	runUI()
+	if task.Value() != 2 {
+		t.Errorf("valeur = %d, attendu celle du dernier lancement", task.Value())
+	}
+}
+
+func TestTaskViewErrorAndRetry(t *testing.T) {
+	test.NewTempApp(t)
+	var calls atomic.Int32
+	task := newTask(func() (string, error) {
+		if calls.Add(1) == 1 {
+			return "", errors.New("réseau coupé")
+		}
+		return "Paris", nil
+	})
+	view := newTaskView(task, "Chargement...", func(s string) fyne.CanvasObject { return widget.NewLabel(s) })
+
+	waitFor(t, "erreur affichée", func() bool { return task.State() == TaskFailed })
+	var retry *widget.Button
+	var message string
+	for _, o := range test.LaidOutObjects(view) {
+		switch w := o.(type) {
+		case *widget.Button:
+			retry = w
+		case *widget.Label:
+			message += w.Text
+		}
+	}
+	if retry == nil || !strings.Contains(message, "réseau coupé") {
+		t.Fatalf("vue d'erreur : bouton %v, message %q", retry, message)
+	}
+
+	test.Tap(retry)
+	waitFor(t, "nouvel essai", func() bool { return task.State() == TaskDone })
+	if label, ok := view.Objects[0].(*widget.Label); !ok || label.Text != "Paris" {
+		t.Errorf("contenu = %#v", view.Objects[0])
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Couleurs propres à l'application, définies par groupieTheme
+const (
+	colorNameCard          fyne.ThemeColorName = "groupieCard"          // Fond des cards
+	colorNameAccent        fyne.ThemeColorName = "groupieAccent"        // Titre et lieux mis en avant
+	colorNameMapBackground fyne.ThemeColorName = "groupieMapBackground" // Fond de la carte, autour de la tuile
+	colorNameChart         fyne.ThemeColorName = "groupieChart"         // Barres des statistiques
+)
+
+// Tailles propres à l'application
+const (
+	sizeNameTitle      fyne.ThemeSizeName = "groupieTitle"      // Titre de la page liste
+	sizeNameCardRadius fyne.ThemeSizeName = "groupieCardRadius" // Arrondi des cards
+)
+
+// Palette de chaque variante ; les couleurs absentes sont celles du thème par défaut
+var brandColors = map[fyne.ThemeVariant]map[fyne.ThemeColorName]color.Color{
+	theme.VariantDark: {
+		colorNameCard:          color.NRGBA{R: 40, G: 40, B: 50, A: 255},
+		colorNameAccent:        color.NRGBA{R: 187, G: 134, B: 252, A: 255},
+		colorNameMapBackground: color.NRGBA{R: 30, G: 30, B: 40, A: 255},
+		colorNameChart:         color.NRGBA{R: 3, G: 218, B: 198, A: 255},
+		theme.ColorNamePrimary: color.NRGBA{R: 187, G: 134, B: 252, A: 255},
+	},
+	theme.VariantLight: {
+		colorNameCard:          color.NRGBA{R: 243, G: 240, B: 248, A: 255},
+		colorNameAccent:        color.NRGBA{R: 98, G: 0, B: 238, A: 255},
+		colorNameMapBackground: color.NRGBA{R: 228, G: 225, B: 236, A: 255},
+		colorNameChart:         color.NRGBA{R: 1, G: 135, B: 134, A: 255},
+		theme.ColorNamePrimary: color.NRGBA{R: 98, G: 0, B: 238, A: 255},
+	},
+}
+
+// groupieTheme est le thème de l'application : celui de Fyne avec les couleurs de Groupie Tracker
+// Il suit la variante du système, sauf si forced impose celle de variant
+type groupieTheme struct {
+	forced  bool
+	variant fyne.ThemeVariant
+}
+
+func (t groupieTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	if t.forced {
+		variant = t.variant
+	}
+	if c, ok := brandColors[variant][name]; ok {
+		return c
+	}
+	return theme.DefaultTheme().Color(name, variant)
+}
+
+func (t groupieTheme) Font(style fyne.TextStyle) fyne.Resource {
+	return theme.DefaultTheme().Font(style)
+}
+
+func (t groupieTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return theme.DefaultTheme().Icon(name)
+}
+
+func (t groupieTheme) Size(name fyne.ThemeSizeName) float32 {
+	switch name {
+	case sizeNameTitle:
+		return 24
+	case sizeNameCardRadius:
+		return 6
+	}
+	return theme.DefaultTheme().Size(name)
+}
+
+// themedRect est un rectangle rempli avec une couleur du thème, mise à jour quand le thème change
+type themedRect struct {
+	widget.BaseWidget
+	color  fyne.ThemeColorName
+	radius fyne.ThemeSizeName // "" : angles droits
+}
+
+func newThemedRect(color fyne.ThemeColorName, radius fyne.ThemeSizeName) *themedRect {
+	r := &themedRect{color: color, radius: radius}
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+func (r *themedRect) CreateRenderer() fyne.WidgetRenderer {
+	rect := canvas.NewRectangle(color.Transparent)
+	renderer := &themedRectRenderer{themedRect: r, rect: rect}
+	renderer.Refresh()
+	return renderer
+}
+
+type themedRectRenderer struct {
+	themedRect *themedRect
+	rect       *canvas.Rectangle
+}
+
+func (r *themedRectRenderer) Layout(size fyne.Size) { r.rect.Resize(size) }
+func (r *themedRectRenderer) MinSize() fyne.Size    { return fyne.NewSize(0, 0) }
+
+func (r *themedRectRenderer) Refresh() {
+	r.rect.FillColor = theme.ColorForWidget(r.themedRect.color, r.themedRect)
+	if r.themedRect.radius != "" {
+		r.rect.CornerRadius = theme.SizeForWidget(r.themedRect.radius, r.themedRect)
+	}
+	r.rect.Refresh()
+}
+
+func (r *themedRectRenderer) Objects() []fyne.CanvasObject { return []fyne.CanvasObject{r.rect} }
+func (r *themedRectRenderer) Destroy()                     {}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+)
+
+func TestThemeVariants(t *testing.T) {
+	dark := brandColors[theme.VariantDark][colorNameCard]
+	light := brandColors[theme.VariantLight][colorNameCard]
+
+	// Le thème « système » suit la variante demandée par Fyne
+	if c := themeFor(themeSystem).Color(colorNameCard, theme.VariantDark); c != dark {
+		t.Errorf("système sombre : card %v, attendu %v", c, dark)
+	}
+	if c := themeFor(themeSystem).Color(colorNameCard, theme.VariantLight); c != light {
+		t.Errorf("système clair : card %v, attendu %v", c, light)
+	}
+
+	// Un choix explicite impose sa variante, y compris pour les couleurs de Fyne
+	if c := themeFor(themeLight).Color(colorNameCard, theme.VariantDark); c != light {
+		t.Errorf("thème clair : card %v, attendu %v", c, light)
+	}
+	bg := themeFor(themeDark).Color(theme.ColorNameBackground, theme.VariantLight)
+	if want := theme.DefaultTheme().Color(theme.ColorNameBackground, theme.VariantDark); bg != want {
+		t.Errorf("thème sombre : fond %v, attendu %v", bg, want)
+	}
+}
+
+func TestCardFollowsTheme(t *testing.T) {
+	a := test.NewTempApp(t)
+	a.Settings().SetTheme(themeFor(themeDark))
+
+	card := createCard(canvas.NewText("Queen", nil))
+	test.NewTempWindow(t, card)
+	rect := test.WidgetRenderer(card.Objects[0].(*themedRect)).Objects()[0].(*canvas.Rectangle)
+	if rect.FillColor != brandColors[theme.VariantDark][colorNameCard] {
+		t.Errorf("fond sombre = %v", rect.FillColor)
+	}
+
+	// Changer de thème recolore les cards déjà affichées
+	a.Settings().SetTheme(themeFor(themeLight))
+	if rect.FillColor != brandColors[theme.VariantLight][colorNameCard] {
+		t.Errorf("fond clair = %v", rect.FillColor)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	api "groupie/models"
+)
+
+// formatGap décrit une pause entre deux concerts dans la langue choisie, ex: "4 mois sans concert"
+func formatGap(days int) string {
+	return plural(api.GapFormat(days))
+}
+
+// newTimeline crée la chronologie de la tournée : concerts triés par date,
+// regroupés par année et par mois, avec les pauses et les enchaînements marqués
+// onSelect est appelé avec le concert cliqué
+func newTimeline(concerts []api.Concert, onSelect func(api.Concert)) fyne.CanvasObject {
+	months := api.BuildTimeline(concerts)
+	if len(months) == 0 {
+		return widget.NewLabel(lang.L("Aucun concert"))
+	}
+
+	box := container.NewVBox()
+	year := 0
+	for _, m := range months {
+		if m.Year != year {
+			year = m.Year
+			box.Add(widget.NewRichTextFromMarkdown(fmt.Sprintf("## %d", year)))
+		}
+		box.Add(widget.NewLabelWithStyle(lang.L(api.MonthNames[m.Month-1]), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+
+		for _, e := range m.Entries {
+			if e.LongGap {
+				gap := widget.NewLabelWithStyle("⋯ "+formatGap(e.DaysSincePrevious), fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+				box.Add(gap)
+			}
+
+			text := fmt.Sprintf("%02d · %s", e.Date.Day(), e.Place())
+			icon := theme.NavigateNextIcon()
+			if e.BackToBack {
+				text += " " + lang.L("(enchaîné)")
+				icon = theme.MediaFastForwardIcon()
+			}
+
+			concert := e.Concert
+			entry := widget.NewButtonWithIcon(text, icon, func() { onSelect(concert) })
+			entry.Alignment = widget.ButtonAlignLeading
+			entry.Importance = widget.LowImportance
+			box.Add(entry)
+		}
+	}
+	return box
+}
@@ -0,0 +1,195 @@
+package web
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	api "groupie/models"
+)
+
+// option est un choix d'une liste déroulante ou d'une case à cocher
+type option struct {
+	Value    string
+	Label    string
+	Selected bool
+}
+
+// artistCard est un artiste de la liste, avec ses concerts
+type artistCard struct {
+	api.Artist
+	Concerts int           // Nombre total de concerts
+	Matches  []api.Concert // Concerts correspondant au filtre de période
+}
+
+// indexPage est la liste des artistes avec la recherche et les filtres
+type indexPage struct {
+	Query                  string
+	Orders                 []option
+	Members                []option
+	CreatedFrom, CreatedTo string
+	Place, From, To        string
+	PeriodFilter           bool     // Un filtre de période est actif
+	Errors                 []string // Saisies invalides, les filtres concernés sont ignorés
+	Results                []artistCard
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page := indexPage{
+		Query:       q.Get("q"),
+		CreatedFrom: q.Get("created_from"),
+		CreatedTo:   q.Get("created_to"),
+		Place:       q.Get("place"),
+		From:        q.Get("from"),
+		To:          q.Get("to"),
+	}
+
+	order, ok := api.ParseSortOrder(q.Get("sort"))
+	if !ok {
+		order = api.SortNameAsc
+	}
+	for _, o := range api.SortOrders {
+		page.Orders = append(page.Orders, option{string(o), o.Label(), o == order})
+	}
+
+	// Nombre de membres : cases cochées, aucune case revient à tout accepter
+	members := map[int]bool{}
+	for _, v := range q["members"] {
+		if n, err := strconv.Atoi(v); err == nil {
+			members[n] = true
+		}
+	}
+	for n := 1; n <= 8; n++ {
+		page.Members = append(page.Members, option{strconv.Itoa(n), strconv.Itoa(n), members[n]})
+	}
+
+	createdFrom, errFrom := parseYear(page.CreatedFrom)
+	createdTo, errTo := parseYear(page.CreatedTo)
+	if errFrom != nil || errTo != nil {
+		page.Errors = append(page.Errors, "Année de création invalide : saisissez une année sur 4 chiffres.")
+	}
+	from, errFrom := api.ParseDateBound(page.From, false)
+	to, errTo := api.ParseDateBound(page.To, true)
+	if errFrom != nil || errTo != nil {
+		page.Errors = append(page.Errors, "Période invalide : saisissez AAAA ou JJ-MM-AAAA.")
+		from, to = time.Time{}, time.Time{}
+	}
+	page.PeriodFilter = !from.IsZero() || !to.IsZero() || strings.TrimSpace(page.Place) != ""
+
+	query := api.ParseQuery(page.Query)
+	var results []api.Artist
+	for _, a := range s.artists {
+		if !query.Match(a, s.concerts[a.ID]) {
+			continue
+		}
+		if createdFrom != 0 && a.CreationDate < createdFrom || createdTo != 0 && a.CreationDate > createdTo {
+			continue
+		}
+		if len(members) > 0 && !members[len(a.Members)] {
+			continue
+		}
+		if page.PeriodFilter && len(api.PlayedBetween(s.concerts[a.ID], from, to, page.Place)) == 0 {
+			continue
+		}
+		results = append(results, a)
+	}
+	api.SortArtists(results, order, s.concerts, query.FreeTerms())
+
+	for _, a := range results {
+		card := artistCard{Artist: a, Concerts: len(s.concerts[a.ID])}
+		if page.PeriodFilter {
+			card.Matches = api.PlayedBetween(s.concerts[a.ID], from, to, page.Place)
+		}
+		page.Results = append(page.Results, card)
+	}
+	s.render(w, http.StatusOK, "index.html", page)
+}
+
+// parseYear lit une année facultative (0 si vide)
+func parseYear(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
+// artistPage est la fiche d'un artiste
+type artistPage struct {
+	api.Artist
+	Concerts  int
+	Countries []string
+	Timeline  []api.TimelineMonth
+}
+
+func (s *Server) handleArtist(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	artist, ok := s.byID[id]
+	if err != nil || !ok {
+		s.notFound(w, "Cet artiste n'existe pas.")
+		return
+	}
+	concerts := s.concerts[artist.ID]
+	s.render(w, http.StatusOK, "artist.html", artistPage{
+		Artist:    artist,
+		Concerts:  len(concerts),
+		Countries: api.Countries(concerts),
+		Timeline:  api.BuildTimeline(concerts),
+	})
+}
+
+// concertRow est une ligne du tableau des concerts
+type concertRow struct {
+	api.Concert
+	Artist string
+}
+
+// concertsPage liste les concerts de tous les artistes
+type concertsPage struct {
+	Artists             []option
+	City, Country, Year string
+	From, To            string
+	Errors              []string
+	Rows                []concertRow
+}
+
+func (s *Server) handleConcerts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page := concertsPage{
+		City:    q.Get("city"),
+		Country: q.Get("country"),
+		Year:    q.Get("year"),
+		From:    q.Get("from"),
+		To:      q.Get("to"),
+	}
+	filter := api.ConcertFilter{City: page.City, Country: page.Country}
+	filter.ArtistID, _ = strconv.Atoi(q.Get("artist"))
+
+	artists := make([]api.Artist, len(s.artists))
+	copy(artists, s.artists)
+	sort.Slice(artists, func(i, j int) bool { return artists[i].Name < artists[j].Name })
+	for _, a := range artists {
+		page.Artists = append(page.Artists, option{strconv.Itoa(a.ID), a.Name, a.ID == filter.ArtistID})
+	}
+
+	var errFrom, errTo error
+	if page.Year != "" {
+		filter.From, errFrom = api.ParseDateBound(page.Year, false)
+		filter.To, errTo = api.ParseDateBound(page.Year, true)
+	} else {
+		filter.From, errFrom = api.ParseDateBound(page.From, false)
+		filter.To, errTo = api.ParseDateBound(page.To, true)
+	}
+	if errFrom != nil || errTo != nil {
+		page.Errors = append(page.Errors, "Période invalide : saisissez AAAA ou JJ-MM-AAAA.")
+		filter.From, filter.To = time.Time{}, time.Time{}
+	}
+
+	for _, c := range api.FindConcerts(s.concerts, filter) {
+		page.Rows = append(page.Rows, concertRow{c, s.byID[c.ArtistID].Name})
+	}
+	s.render(w, http.StatusOK, "concerts.html", page)
+}
@@ -0,0 +1,371 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	api "groupie/models"
+)
+
+// Pagination de l'API REST
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// isoDate est le format des dates renvoyées par l'API REST (ISO 8601)
+const isoDate = "2006-01-02"
+
+// artistResource est un artiste tel que renvoyé par /v1/artists
+type artistResource struct {
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	Image        string   `json:"image"`
+	Members      []string `json:"members"`
+	CreationDate int      `json:"creationDate"`
+	FirstAlbum   string   `json:"firstAlbum"`
+	ConcertCount int      `json:"concertCount"`
+	Countries    []string `json:"countries"`
+}
+
+// artistDetailsResource est un artiste et ses concerts, tel que renvoyé par /v1/artists/{id}
+type artistDetailsResource struct {
+	artistResource
+	Concerts []concertResource `json:"concerts"`
+}
+
+// concertResource est un concert tel que renvoyé par /v1/concerts
+type concertResource struct {
+	ArtistID int    `json:"artistId"`
+	Artist   string `json:"artist,omitempty"`
+	Date     string `json:"date"`
+	City     string `json:"city"`
+	Country  string `json:"country"`
+	Location string `json:"location"`
+}
+
+// locationResource est un lieu de concert, avec ses coordonnées si elles sont connues
+type locationResource struct {
+	Location string   `json:"location"`
+	City     string   `json:"city"`
+	Country  string   `json:"country"`
+	Concerts int      `json:"concerts"`
+	Artists  []int    `json:"artists"`
+	Lat      *float64 `json:"lat,omitempty"`
+	Lon      *float64 `json:"lon,omitempty"`
+	place    string   // Nom du lieu pour le géocodeur, ex: "Paris, France"
+}
+
+// pageResource enveloppe une liste paginée
+type pageResource struct {
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+	Items  any `json:"items"`
+}
+
+// registerREST ajoute les routes de l'API REST
+func (s *Server) registerREST() {
+	s.mux.HandleFunc("GET /v1/artists", s.apiArtists)
+	s.mux.HandleFunc("GET /v1/artists/{id}", s.apiArtist)
+	s.mux.HandleFunc("GET /v1/concerts", s.apiConcerts)
+	s.mux.HandleFunc("GET /v1/locations", s.apiLocations)
+	s.mux.HandleFunc("GET /v1/stats", s.apiStats)
+	s.mux.HandleFunc("GET /v1/openapi.json", s.apiOpenAPI)
+	s.mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "ressource inconnue : "+r.URL.Path)
+	})
+}
+
+func (s *Server) apiArtists(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	offset, limit, err := pagination(q.Get("offset"), q.Get("limit"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	createdFrom, errFrom := parseYear(q.Get("created_from"))
+	createdTo, errTo := parseYear(q.Get("created_to"))
+	if errFrom != nil || errTo != nil {
+		writeError(w, http.StatusBadRequest, "created_from et created_to doivent être des années")
+		return
+	}
+	order := api.SortNameAsc
+	if v := q.Get("sort"); v != "" {
+		var ok bool
+		if order, ok = api.ParseSortOrder(v); !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("ordre de tri inconnu %q", v))
+			return
+		}
+	}
+
+	// member, country et city sont des raccourcis vers la recherche par champ
+	query := api.ParseQuery(q.Get("q"))
+	if v := strings.ToLower(strings.TrimSpace(q.Get("member"))); v != "" {
+		query = append(query, api.Term{Field: api.FieldMember, Value: v})
+	}
+	country := strings.ToLower(strings.TrimSpace(q.Get("country")))
+	city := strings.ToLower(strings.TrimSpace(q.Get("city")))
+
+	var results []api.Artist
+	for _, a := range s.artists {
+		if !query.Match(a, s.concerts[a.ID]) {
+			continue
+		}
+		if createdFrom != 0 && a.CreationDate < createdFrom || createdTo != 0 && a.CreationDate > createdTo {
+			continue
+		}
+		if (country != "" || city != "") && len(api.FindConcerts(s.concerts, api.ConcertFilter{ArtistID: a.ID, City: city, Country: country})) == 0 {
+			continue
+		}
+		results = append(results, a)
+	}
+	api.SortArtists(results, order, s.concerts, query.FreeTerms())
+
+	items := make([]artistResource, 0, limit)
+	for _, a := range pageOf(results, offset, limit) {
+		items = append(items, s.artistResource(a))
+	}
+	writePage(w, r, len(results), offset, limit, items)
+}
+
+func (s *Server) apiArtist(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	artist, ok := s.byID[id]
+	if err != nil || !ok {
+		writeError(w, http.StatusNotFound, "artiste introuvable : "+r.PathValue("id"))
+		return
+	}
+	concerts := s.concerts[artist.ID]
+	details := artistDetailsResource{
+		artistResource: s.artistResource(artist),
+		Concerts:       make([]concertResource, 0, len(concerts)),
+	}
+	for _, c := range concerts {
+		details.Concerts = append(details.Concerts, newConcertResource(c, ""))
+	}
+	item, err := selectFields(details, r.URL.Query().Get("fields"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+// artistResource prépare un artiste, sans la liste de ses concerts
+func (s *Server) artistResource(a api.Artist) artistResource {
+	concerts := s.concerts[a.ID]
+	res := artistResource{
+		ID:           a.ID,
+		Name:         a.Name,
+		Image:        a.Image,
+		Members:      a.Members,
+		CreationDate: a.CreationDate,
+		FirstAlbum:   a.FirstAlbum,
+		ConcertCount: len(concerts),
+		Countries:    api.Countries(concerts),
+	}
+	if res.Members == nil {
+		res.Members = []string{}
+	}
+	return res
+}
+
+func newConcertResource(c api.Concert, artist string) concertResource {
+	return concertResource{
+		ArtistID: c.ArtistID,
+		Artist:   artist,
+		Date:     c.Date.Format(isoDate),
+		City:     c.City,
+		Country:  c.Country,
+		Location: c.Location,
+	}
+}
+
+func (s *Server) apiConcerts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	offset, limit, err := pagination(q.Get("offset"), q.Get("limit"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter := api.ConcertFilter{City: q.Get("city"), Country: q.Get("country")}
+	if v := q.Get("artist"); v != "" {
+		if filter.ArtistID, err = strconv.Atoi(v); err != nil {
+			writeError(w, http.StatusBadRequest, "artist doit être un identifiant d'artiste")
+			return
+		}
+	}
+	var errFrom, errTo error
+	filter.From, errFrom = api.ParseDateBound(q.Get("from"), false)
+	filter.To, errTo = api.ParseDateBound(q.Get("to"), true)
+	if errFrom != nil || errTo != nil {
+		writeError(w, http.StatusBadRequest, "from et to attendent AAAA ou JJ-MM-AAAA")
+		return
+	}
+
+	concerts := api.FindConcerts(s.concerts, filter)
+	items := make([]concertResource, 0, limit)
+	for _, c := range pageOf(concerts, offset, limit) {
+		items = append(items, newConcertResource(c, s.byID[c.ArtistID].Name))
+	}
+	writePage(w, r, len(concerts), offset, limit, items)
+}
+
+func (s *Server) apiLocations(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	offset, limit, err := pagination(q.Get("offset"), q.Get("limit"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	city := strings.ToLower(strings.TrimSpace(q.Get("city")))
+	country := strings.ToLower(strings.TrimSpace(q.Get("country")))
+
+	var locations []locationResource
+	for _, l := range s.locations() {
+		if city != "" && !strings.Contains(strings.ToLower(l.City), city) {
+			continue
+		}
+		if country != "" && !strings.Contains(strings.ToLower(l.Country), country) {
+			continue
+		}
+		locations = append(locations, l)
+	}
+
+	// Seules les coordonnées déjà trouvées sont renvoyées : la réponse n'attend jamais le géocodeur
+	items := pageOf(locations, offset, limit)
+	if s.geo != nil {
+		for i := range items {
+			if lat, lon, ok := s.geo(items[i].place); ok {
+				items[i].Lat, items[i].Lon = &lat, &lon
+			}
+		}
+	}
+	if items == nil {
+		items = []locationResource{}
+	}
+	writePage(w, r, len(locations), offset, limit, items)
+}
+
+// locations regroupe les concerts par lieu, triés par nom de lieu
+func (s *Server) locations() []locationResource {
+	byLocation := map[string]*locationResource{}
+	for id, concerts := range s.concerts {
+		for _, c := range concerts {
+			l, ok := byLocation[c.Location]
+			if !ok {
+				l = &locationResource{Location: c.Location, City: c.City, Country: c.Country, place: c.Place()}
+				byLocation[c.Location] = l
+			}
+			l.Concerts++
+			if n := len(l.Artists); n == 0 || l.Artists[n-1] != id {
+				l.Artists = append(l.Artists, id)
+			}
+		}
+	}
+	locations := make([]locationResource, 0, len(byLocation))
+	for _, l := range byLocation {
+		sort.Ints(l.Artists)
+		locations = append(locations, *l)
+	}
+	sort.Slice(locations, func(i, j int) bool { return locations[i].Location < locations[j].Location })
+	return locations
+}
+
+func (s *Server) apiStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, api.ComputeStats(s.artists, s.concerts))
+}
+
+func (s *Server) apiOpenAPI(w http.ResponseWriter, r *http.Request) {
+	data, err := files.ReadFile("openapi.json")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "document OpenAPI indisponible")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// pagination lit offset et limit (par défaut 0 et 20, au plus 100)
+func pagination(offsetParam, limitParam string) (offset, limit int, err error) {
+	limit = defaultLimit
+	if offsetParam != "" {
+		if offset, err = strconv.Atoi(offsetParam); err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("offset doit être un entier positif")
+		}
+	}
+	if limitParam != "" {
+		if limit, err = strconv.Atoi(limitParam); err != nil || limit < 1 || limit > maxLimit {
+			return 0, 0, fmt.Errorf("limit doit être compris entre 1 et %d", maxLimit)
+		}
+	}
+	return offset, limit, nil
+}
+
+// pageOf renvoie les éléments de la page demandée
+func pageOf[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	return items[offset:min(offset+limit, len(items))]
+}
+
+// writePage écrit une liste paginée, en ne gardant que les champs demandés (?fields=id,name)
+func writePage[T any](w http.ResponseWriter, r *http.Request, total, offset, limit int, items []T) {
+	fields := r.URL.Query().Get("fields")
+	selected := make([]any, 0, len(items))
+	for _, item := range items {
+		v, err := selectFields(item, fields)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		selected = append(selected, v)
+	}
+	writeJSON(w, http.StatusOK, pageResource{Total: total, Offset: offset, Limit: limit, Items: selected})
+}
+
+// selectFields ne garde que les champs listés de l'objet JSON (tous si fields est vide)
+func selectFields(item any, fields string) (any, error) {
+	if strings.TrimSpace(fields) == "" {
+		return item, nil
+	}
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	kept := map[string]json.RawMessage{}
+	for _, f := range strings.Split(fields, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		v, ok := all[f]
+		if !ok {
+			return nil, fmt.Errorf("champ inconnu : %q", f)
+		}
+		kept[f] = v
+	}
+	return kept, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,106 @@
+package web_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	api "groupie/models"
+	"groupie/web"
+)
+
+// getJSON envoie une requête à l'API REST et décode la réponse
+func getJSON(t *testing.T, url string, v any) int {
+	t.Helper()
+	status, body := get(t, url)
+	if err := json.Unmarshal([]byte(body), v); err != nil {
+		t.Fatalf("%s : réponse invalide : %v\n%s", url, err, body)
+	}
+	return status
+}
+
+func TestAPIArtistWithoutConcerts(t *testing.T) {
+	ts := newServer(t)
+
+	// Ace of Queens n'a aucun concert : la liste est vide, mais présente
+	var artist map[string]any
+	if status := getJSON(t, ts.URL+"/v1/artists/2", &artist); status != http.StatusOK {
+		t.Fatalf("status = %d, attendu 200", status)
+	}
+	if concerts, ok := artist["concerts"].([]any); !ok || len(concerts) != 0 {
+		t.Errorf("concerts = %v, attendu une liste vide", artist["concerts"])
+	}
+
+	var fields map[string]any
+	if status := getJSON(t, ts.URL+"/v1/artists/2?fields=id,concerts", &fields); status != http.StatusOK {
+		t.Fatalf("fields=id,concerts : status = %d, attendu 200", status)
+	}
+	if len(fields) != 2 {
+		t.Errorf("champs = %v, attendu id et concerts", fields)
+	}
+
+	// La liste des artistes ne détaille pas les concerts
+	var page struct {
+		Items []map[string]any `json:"items"`
+	}
+	getJSON(t, ts.URL+"/v1/artists", &page)
+	for _, item := range page.Items {
+		if _, ok := item["concerts"]; ok {
+			t.Errorf("concerts présent dans la liste : %v", item)
+		}
+	}
+}
+
+func TestAPIFieldsSkipsEmpty(t *testing.T) {
+	ts := newServer(t)
+
+	for _, fields := range []string{"id,", "id,,name", " id , "} {
+		var artist map[string]any
+		if status := getJSON(t, ts.URL+"/v1/artists/1?fields="+url.QueryEscape(fields), &artist); status != http.StatusOK {
+			t.Errorf("fields=%q : status = %d, attendu 200", fields, status)
+		}
+		if artist["id"] != float64(1) {
+			t.Errorf("fields=%q : %v, attendu l'identifiant", fields, artist)
+		}
+	}
+}
+
+func TestAPILocationsCoordinates(t *testing.T) {
+	artists := []api.Artist{{ID: 1, Name: "Queen"}}
+	concerts := api.BuildConcertIndex([]api.RelationData{
+		{ID: 1, DatesLocations: map[string][]string{"paris-france": {"01-09-2019"}, "atlantis": {"01-10-2019"}}},
+	})
+	// Le géocodeur garde les lieux sous le nom de Concert.Place
+	known := map[string][2]float64{}
+	for _, c := range concerts[1] {
+		known[c.Place()] = [2]float64{48.85, 2.35}
+	}
+	s, err := web.New(artists, concerts, func(place string) (float64, float64, bool) {
+		coords, ok := known[place]
+		return coords[0], coords[1], ok
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	var page struct {
+		Items []struct {
+			Location string   `json:"location"`
+			Lat      *float64 `json:"lat"`
+			Lon      *float64 `json:"lon"`
+		} `json:"items"`
+	}
+	getJSON(t, ts.URL+"/v1/locations", &page)
+	if len(page.Items) != 2 {
+		t.Fatalf("%d lieux, attendu 2", len(page.Items))
+	}
+	for _, l := range page.Items {
+		if l.Lat == nil || l.Lon == nil {
+			t.Errorf("%s : coordonnées absentes", l.Location)
+		}
+	}
+}
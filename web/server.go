@@ -0,0 +1,144 @@
+// Package web sert le tracker sous forme de pages HTML générées côté serveur
+package web
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	api "groupie/models"
+)
+
+// Gabarits, fichiers statiques et description OpenAPI, embarqués dans l'exécutable
+//
+//go:embed templates static openapi.json
+var files embed.FS
+
+// Fonctions disponibles dans les gabarits
+var funcs = template.FuncMap{
+	"date":  func(t time.Time) string { return t.Format(api.DateLayout) },
+	"month": func(m time.Month) string { return api.MonthNames[m-1] },
+	"join":  strings.Join,
+	"gap":   formatGap,
+}
+
+// formatGap décrit une pause entre deux concerts, ex: "4 mois sans concert"
+func formatGap(days int) string {
+	format, n := api.GapFormat(days)
+	return fmt.Sprintf(format, n)
+}
+
+// Server sert les pages du tracker
+// Les données sont chargées une fois au démarrage et ne sont jamais modifiées
+type Server struct {
+	artists  []api.Artist
+	byID     map[int]api.Artist
+	concerts map[int][]api.Concert
+	geo      api.GeoLookup
+	pages    map[string]*template.Template
+	mux      *http.ServeMux
+}
+
+// New crée le serveur à partir des artistes et de l'index de leurs concerts
+// geo donne les coordonnées déjà connues des lieux pour /v1/locations (nil : pas de coordonnées)
+func New(artists []api.Artist, concerts map[int][]api.Concert, geo api.GeoLookup) (*Server, error) {
+	s := &Server{
+		artists:  artists,
+		byID:     make(map[int]api.Artist, len(artists)),
+		concerts: concerts,
+		geo:      geo,
+		pages:    map[string]*template.Template{},
+		mux:      http.NewServeMux(),
+	}
+	for _, a := range artists {
+		s.byID[a.ID] = a
+	}
+
+	// Chaque page est associée au gabarit commun base.html
+	for _, page := range []string{"index.html", "artist.html", "concerts.html", "error.html"} {
+		t, err := template.New(page).Funcs(funcs).ParseFS(files, "templates/base.html", "templates/"+page)
+		if err != nil {
+			return nil, err
+		}
+		s.pages[page] = t
+	}
+
+	s.mux.HandleFunc("GET /{$}", s.handleIndex)
+	s.mux.HandleFunc("GET /artists/{id}", s.handleArtist)
+	s.mux.HandleFunc("GET /concerts", s.handleConcerts)
+	s.mux.HandleFunc("GET /static/", s.handleStatic)
+	s.registerREST()
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		s.notFound(w, "Cette page n'existe pas.")
+	})
+	return s, nil
+}
+
+// ServeHTTP sert une requête ; une panique dans un handler donne une erreur 500
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Printf("%s %s : %v", r.Method, r.URL.Path, err)
+			if strings.HasPrefix(r.URL.Path, "/v1/") {
+				writeError(w, http.StatusInternalServerError, "erreur interne")
+				return
+			}
+			s.serverError(w, fmt.Errorf("%v", err))
+		}
+	}()
+	s.mux.ServeHTTP(w, r)
+}
+
+// handleStatic sert les fichiers du dossier static (feuille de style...)
+func (s *Server) handleStatic(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	info, err := fs.Stat(files, name)
+	if err != nil || info.IsDir() {
+		s.notFound(w, "Ce fichier n'existe pas.")
+		return
+	}
+	http.ServeFileFS(w, r, files, name)
+}
+
+// render génère une page complète avant de l'envoyer
+// Une erreur de gabarit donne ainsi une vraie page 500, et non une page tronquée
+func (s *Server) render(w http.ResponseWriter, status int, page string, data any) {
+	var buf bytes.Buffer
+	if err := s.pages[page].ExecuteTemplate(&buf, "base", data); err != nil {
+		if page == "error.html" {
+			log.Println("Page d'erreur:", err)
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		s.serverError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	buf.WriteTo(w)
+}
+
+// errorPage décrit une page d'erreur
+type errorPage struct {
+	Status  int
+	Title   string
+	Message string
+}
+
+func (s *Server) notFound(w http.ResponseWriter, message string) {
+	s.render(w, http.StatusNotFound, "error.html", errorPage{http.StatusNotFound, "Page introuvable", message})
+}
+
+// serverError journalise l'erreur et affiche une page 500 sans détail technique
+func (s *Server) serverError(w http.ResponseWriter, err error) {
+	log.Println("Erreur serveur:", err)
+	s.render(w, http.StatusInternalServerError, "error.html", errorPage{
+		http.StatusInternalServerError, "Erreur interne", "Une erreur est survenue, réessayez plus tard.",
+	})
+}
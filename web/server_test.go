@@ -0,0 +1,133 @@
+package web_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	api "groupie/models"
+	"groupie/web"
+)
+
+// newServer crée un serveur avec trois artistes et quelques concerts
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	artists := []api.Artist{
+		{ID: 1, Name: "Queen", Members: []string{"Freddie Mercury", "Brian May"}, CreationDate: 1970, FirstAlbum: "14-12-1973"},
+		{ID: 2, Name: "Ace of Queens", Members: []string{"Ann Smith"}, CreationDate: 1999, FirstAlbum: "01-01-2001"},
+		{ID: 3, Name: "Pink Floyd", Members: []string{"Roger Waters", "David Gilmour"}, CreationDate: 1965, FirstAlbum: "05-08-1967"},
+	}
+	concerts := api.BuildConcertIndex([]api.RelationData{
+		{ID: 1, DatesLocations: map[string][]string{"london-uk": {"01-03-2019"}, "paris-france": {"01-09-2019"}}},
+		{ID: 3, DatesLocations: map[string][]string{"berlin-germany": {"12-05-2018"}}},
+	})
+	s, err := web.New(artists, concerts, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// get envoie une requête au serveur et renvoie le statut et la page
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+// order renvoie les positions des textes dans la page (-1 si absent)
+func order(body string, texts ...string) []int {
+	pos := make([]int, len(texts))
+	for i, text := range texts {
+		pos[i] = strings.Index(body, text)
+	}
+	return pos
+}
+
+func TestIndex(t *testing.T) {
+	ts := newServer(t)
+
+	status, body := get(t, ts.URL+"/")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, attendu 200", status)
+	}
+	for _, name := range []string{"Queen", "Ace of Queens", "Pink Floyd"} {
+		if !strings.Contains(body, name) {
+			t.Errorf("%q absent de la liste", name)
+		}
+	}
+
+	_, body = get(t, ts.URL+"/?q=member:freddie")
+	if !strings.Contains(body, "Queen") || strings.Contains(body, "Pink Floyd") {
+		t.Errorf("la recherche member:freddie doit garder Queen seul :\n%s", body)
+	}
+}
+
+func TestIndexRelevanceUsesFreeTerms(t *testing.T) {
+	ts := newServer(t)
+
+	// "Queen" est le nom exact : il passe devant "Ace of Queens", premier par ordre alphabétique
+	_, body := get(t, ts.URL+"/?q=name:queen&sort=relevance")
+	pos := order(body, "<strong>Queen</strong>", "<strong>Ace of Queens</strong>")
+	if pos[0] < 0 || pos[1] < 0 || pos[0] > pos[1] {
+		t.Errorf("positions de Queen et Ace of Queens = %v, attendu Queen en premier", pos)
+	}
+}
+
+func TestIndexInvalidQuery(t *testing.T) {
+	ts := newServer(t)
+
+	status, body := get(t, ts.URL+"/?created_from=soixante&from=hier")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, attendu 200", status)
+	}
+	// Les deux erreurs sont affichées, et les filtres invalides ignorés
+	for _, msg := range []string{"Année de création invalide", "Période invalide"} {
+		if !strings.Contains(body, msg) {
+			t.Errorf("message %q absent de la page", msg)
+		}
+	}
+	if !strings.Contains(body, "Pink Floyd") {
+		t.Error("les filtres invalides ne doivent pas vider la liste")
+	}
+}
+
+func TestArtistPage(t *testing.T) {
+	ts := newServer(t)
+
+	status, body := get(t, ts.URL+"/artists/1")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, attendu 200", status)
+	}
+	for _, text := range []string{"Queen", "Freddie Mercury", "London", "Mars", "Septembre", "6 mois sans concert"} {
+		if !strings.Contains(body, text) {
+			t.Errorf("%q absent de la fiche", text)
+		}
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	ts := newServer(t)
+
+	for _, path := range []string{"/artists/42", "/artists/queen", "/nulle-part", "/static/absent.css"} {
+		status, body := get(t, ts.URL+path)
+		if status != http.StatusNotFound {
+			t.Errorf("%s : status = %d, attendu 404", path, status)
+		}
+		if !strings.Contains(body, "Page introuvable") {
+			t.Errorf("%s : page d'erreur attendue", path)
+		}
+	}
+}